@@ -0,0 +1,185 @@
+// Package apikey issues and verifies API keys of the form <prefix>_<env>_<id>_<secret>_<crc>,
+// e.g. gb_live_0123456789ab_<32 chars>_1a2B3c.
+//
+// The CRC32 checksum lets clients and secret scanners detect mistyped or truncated keys offline.
+// Servers store only a Record holding the lookup ID and a SHA-256 hash of the key; a slow
+// password hash is unnecessary because the key is long and random.
+package apikey
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"hash/crc32"
+	"strings"
+
+	"github.com/ducconit/gobase/utils"
+)
+
+// checksumLength is the length of the base62 encoded CRC32 checksum.
+const checksumLength = 6
+
+// base62 is the alphabet of the checksum.
+const base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// Errors returned by Parse.
+var (
+	ErrMalformed = errors.New("apikey: malformed key")
+	ErrChecksum  = errors.New("apikey: checksum mismatch")
+)
+
+// Issuer creates API keys.
+type Issuer struct {
+	// Prefix identifies the issuing product, e.g. "gb". It must be alphanumeric.
+	Prefix string
+
+	// Environment separates key spaces such as "live" and "test". It must be alphanumeric.
+	Environment string
+
+	// IDLength is the length of the random lookup ID.
+	IDLength int
+
+	// SecretLength is the length of the random secret.
+	SecretLength int
+}
+
+// DefaultIssuer is the issuer used by Issue.
+var DefaultIssuer = Issuer{
+	Prefix:       "gb",
+	Environment:  "live",
+	IDLength:     12,
+	SecretLength: 32,
+}
+
+// Key is a parsed API key.
+type Key struct {
+	Prefix      string
+	Environment string
+	ID          string
+	Secret      string
+	Checksum    string
+}
+
+// String returns the key in its plaintext form.
+func (k Key) String() string {
+	return k.body() + "_" + k.Checksum
+}
+
+// body returns the checksummed part of the key.
+func (k Key) body() string {
+	return strings.Join([]string{k.Prefix, k.Environment, k.ID, k.Secret}, "_")
+}
+
+// Record is what servers store for an issued key. It does not contain the secret.
+type Record struct {
+	// ID is the lookup ID used to find the record when a key is presented.
+	ID string `json:"id"`
+
+	// Prefix is the non-secret start of the key, e.g. "gb_live_0123456789ab", for display.
+	Prefix string `json:"prefix"`
+
+	// Hash is the hex encoded SHA-256 hash of the full key.
+	Hash string `json:"hash"`
+}
+
+// Issue creates a new key with DefaultIssuer.
+// The plaintext is shown to the user once; only the record is stored.
+func Issue() (plaintext string, record Record, err error) {
+	return DefaultIssuer.Issue()
+}
+
+// Issue creates a new key. The plaintext is shown to the user once; only the record is stored.
+func (i Issuer) Issue() (plaintext string, record Record, err error) {
+	if !isAlphaNumeric(i.Prefix) || !isAlphaNumeric(i.Environment) {
+		return "", Record{}, errors.New("apikey: prefix and environment must be alphanumeric")
+	}
+
+	id, err := utils.GenerateRandomString(utils.AlphaNumeric, i.IDLength)
+	if err != nil {
+		return "", Record{}, err
+	}
+	secret, err := utils.GenerateRandomString(utils.AlphaNumeric, i.SecretLength)
+	if err != nil {
+		return "", Record{}, err
+	}
+
+	k := Key{Prefix: i.Prefix, Environment: i.Environment, ID: id, Secret: secret}
+	k.Checksum = checksum(k.body())
+
+	plaintext = k.String()
+	return plaintext, Record{
+		ID:     id,
+		Prefix: k.Prefix + "_" + k.Environment + "_" + k.ID,
+		Hash:   Hash(plaintext),
+	}, nil
+}
+
+// Parse splits a key into its parts and validates the checksum.
+// It returns ErrMalformed or ErrChecksum, so typos are caught without a database lookup.
+func Parse(key string) (Key, error) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 5 {
+		return Key{}, ErrMalformed
+	}
+	for _, part := range parts {
+		if !isAlphaNumeric(part) {
+			return Key{}, ErrMalformed
+		}
+	}
+
+	k := Key{
+		Prefix:      parts[0],
+		Environment: parts[1],
+		ID:          parts[2],
+		Secret:      parts[3],
+		Checksum:    parts[4],
+	}
+	if len(k.Checksum) != checksumLength {
+		return Key{}, ErrMalformed
+	}
+	if subtle.ConstantTimeCompare([]byte(k.Checksum), []byte(checksum(k.body()))) != 1 {
+		return Key{}, ErrChecksum
+	}
+	return k, nil
+}
+
+// Verify checks a presented key against a stored record in constant time.
+func Verify(key string, record Record) bool {
+	k, err := Parse(key)
+	if err != nil || k.ID != record.ID {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(Hash(key)), []byte(record.Hash)) == 1
+}
+
+// Hash returns the hex encoded SHA-256 hash of the key.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// checksum returns the base62 encoded CRC32 of s, padded to checksumLength.
+func checksum(s string) string {
+	n := crc32.ChecksumIEEE([]byte(s))
+
+	buf := make([]byte, checksumLength)
+	for i := checksumLength - 1; i >= 0; i-- {
+		buf[i] = base62[n%62]
+		n /= 62
+	}
+	return string(buf)
+}
+
+// isAlphaNumeric reports whether s is a non-empty ASCII alphanumeric string.
+func isAlphaNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune(utils.AlphaNumeric, r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+package apikey
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestIssue(t *testing.T) {
+	plaintext, record, err := Issue()
+	if err != nil {
+		t.Fatalf("Issue() failed: %v", err)
+	}
+
+	if !regexp.MustCompile(`^gb_live_[A-Za-z0-9]{12}_[A-Za-z0-9]{32}_[A-Za-z0-9]{6}$`).MatchString(plaintext) {
+		t.Errorf("Issue() = %s, want gb_live_<id>_<secret>_<crc>", plaintext)
+	}
+
+	if !strings.HasPrefix(plaintext, record.Prefix+"_") {
+		t.Errorf("Record.Prefix = %s, want prefix of %s", record.Prefix, plaintext)
+	}
+	if record.ID != strings.Split(plaintext, "_")[2] {
+		t.Errorf("Record.ID = %s, want id of %s", record.ID, plaintext)
+	}
+	if record.Hash != Hash(plaintext) || strings.Contains(record.Hash, plaintext) {
+		t.Errorf("Record.Hash = %s, want sha256 of key", record.Hash)
+	}
+
+	if !Verify(plaintext, record) {
+		t.Error("Verify() should return true for issued key")
+	}
+}
+
+func TestIssuerIssue(t *testing.T) {
+	i := Issuer{Prefix: "acme", Environment: "test", IDLength: 8, SecretLength: 24}
+
+	plaintext, _, err := i.Issue()
+	if err != nil {
+		t.Fatalf("Issue() failed: %v", err)
+	}
+
+	k, err := Parse(plaintext)
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if k.Prefix != "acme" || k.Environment != "test" || len(k.ID) != 8 || len(k.Secret) != 24 {
+		t.Errorf("Parse() = %+v, want issuer settings", k)
+	}
+	if k.String() != plaintext {
+		t.Errorf("String() = %s, want %s", k.String(), plaintext)
+	}
+
+	if _, _, err := (Issuer{Prefix: "a_b", Environment: "live", IDLength: 8, SecretLength: 8}).Issue(); err == nil {
+		t.Error("Issue() should reject prefix with underscore")
+	}
+	if _, _, err := (Issuer{Prefix: "gb", Environment: "live"}).Issue(); err == nil {
+		t.Error("Issue() should reject zero lengths")
+	}
+}
+
+func TestParse(t *testing.T) {
+	plaintext, _, err := Issue()
+	if err != nil {
+		t.Fatalf("Issue() failed: %v", err)
+	}
+
+	// Change one character of the secret to simulate a typo.
+	parts := strings.Split(plaintext, "_")
+	if parts[3][0] == 'a' {
+		parts[3] = "b" + parts[3][1:]
+	} else {
+		parts[3] = "a" + parts[3][1:]
+	}
+	typo := strings.Join(parts, "_")
+
+	tests := []struct {
+		name    string
+		key     string
+		wantErr error
+	}{
+		{name: "valid", key: plaintext, wantErr: nil},
+		{name: "typo", key: typo, wantErr: ErrChecksum},
+		{name: "truncated", key: plaintext[:len(plaintext)-1], wantErr: ErrMalformed},
+		{name: "missing parts", key: "gb_live_abc", wantErr: ErrMalformed},
+		{name: "invalid characters", key: "gb_live_ab-c_secret_AAAAAA", wantErr: ErrMalformed},
+		{name: "empty", key: "", wantErr: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	plaintext, record, err := Issue()
+	if err != nil {
+		t.Fatalf("Issue() failed: %v", err)
+	}
+	other, otherRecord, err := Issue()
+	if err != nil {
+		t.Fatalf("Issue() failed: %v", err)
+	}
+
+	if Verify(other, record) {
+		t.Error("Verify() should return false for another key")
+	}
+	if Verify(plaintext, otherRecord) {
+		t.Error("Verify() should return false for another record")
+	}
+	if Verify(plaintext, Record{ID: record.ID, Hash: otherRecord.Hash}) {
+		t.Error("Verify() should return false for mismatched hash")
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	if got := checksum(""); got != "000000" {
+		t.Errorf("checksum(\"\") = %s, want 000000", got)
+	}
+	if got := checksum("gb_live_id_secret"); len(got) != checksumLength {
+		t.Errorf("checksum() = %s, want %d characters", got, checksumLength)
+	}
+}
@@ -0,0 +1,164 @@
+// Package apperror provides typed application errors declared once in a catalog,
+// so handlers return errors instead of choosing HTTP statuses and codes themselves.
+//
+// Services declare their domain errors at package level:
+//
+//	var ErrOrderClosed = apperror.Define("ORDER_CLOSED", http.StatusConflict, "order.closed", "Order is closed")
+//
+// and return them with context attached:
+//
+//	return ErrOrderClosed.WithCause(err).WithDetails(map[string]any{"order_id": id})
+//
+// httputil.Fail renders such errors with their status and code.
+package apperror
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// AppError is an application error with a stable code and HTTP status.
+type AppError struct {
+	// Code is the application error code written to responses.
+	Code string
+
+	// Status is the HTTP status code.
+	Status int
+
+	// MessageKey identifies the message for translation, e.g. "order.closed".
+	MessageKey string
+
+	// Message is the default, client-safe message.
+	Message string
+
+	// Details holds additional client-safe information, such as the offending field.
+	Details map[string]any
+
+	// Cause is the underlying error. It is logged but never sent to clients.
+	Cause error
+}
+
+// Error returns the code, message and cause.
+func (e *AppError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.Cause)
+	}
+	return e.Code + ": " + e.Message
+}
+
+// Unwrap returns the cause.
+func (e *AppError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is an AppError with the same code,
+// so errors.Is(err, ErrOrderClosed) matches copies made by the With methods.
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t != nil && t.Code == e.Code
+}
+
+// WithCause returns a copy of the error with the cause attached.
+func (e *AppError) WithCause(err error) *AppError {
+	c := *e
+	c.Cause = err
+	return &c
+}
+
+// WithDetails returns a copy of the error with the details merged into its details.
+func (e *AppError) WithDetails(details map[string]any) *AppError {
+	c := *e
+	c.Details = make(map[string]any, len(e.Details)+len(details))
+	maps.Copy(c.Details, e.Details)
+	maps.Copy(c.Details, details)
+	return &c
+}
+
+// WithMessage returns a copy of the error with a different client-safe message.
+func (e *AppError) WithMessage(message string) *AppError {
+	c := *e
+	c.Message = message
+	return &c
+}
+
+// Catalog holds the declared errors, indexed by code.
+type Catalog struct {
+	mu     sync.RWMutex
+	byCode map[string]*AppError
+}
+
+// NewCatalog creates an empty catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{byCode: make(map[string]*AppError)}
+}
+
+// Define declares an error. It panics if the code is empty, already declared
+// or the status is not an error status, as errors are declared during initialization.
+func (c *Catalog) Define(code string, status int, messageKey, message string) *AppError {
+	if code == "" {
+		panic("apperror: empty code")
+	}
+	if status < 400 || status > 599 {
+		panic(fmt.Sprintf("apperror: invalid status %d for code %q", status, code))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.byCode[code]; ok {
+		panic(fmt.Sprintf("apperror: code %q already defined", code))
+	}
+
+	e := &AppError{Code: code, Status: status, MessageKey: messageKey, Message: message}
+	c.byCode[code] = e
+	return e
+}
+
+// Lookup returns the error declared with the code.
+func (c *Catalog) Lookup(code string) (*AppError, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.byCode[code]
+	return e, ok
+}
+
+// All returns the declared errors sorted by code, e.g. for generating documentation.
+func (c *Catalog) All() []*AppError {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	all := slices.Collect(maps.Values(c.byCode))
+	slices.SortFunc(all, func(a, b *AppError) int {
+		return cmp.Compare(a.Code, b.Code)
+	})
+	return all
+}
+
+// DefaultCatalog is the catalog used by Define and Lookup.
+var DefaultCatalog = NewCatalog()
+
+// Define declares an error in DefaultCatalog.
+func Define(code string, status int, messageKey, message string) *AppError {
+	return DefaultCatalog.Define(code, status, messageKey, message)
+}
+
+// Lookup returns the error declared with the code in DefaultCatalog.
+func Lookup(code string) (*AppError, bool) {
+	return DefaultCatalog.Lookup(code)
+}
+
+// Generic errors, using the same codes as the httputil helpers.
+var (
+	ErrBadRequest         = Define("400", http.StatusBadRequest, "error.bad_request", "Bad request")
+	ErrUnauthorized       = Define("401", http.StatusUnauthorized, "error.unauthorized", "Unauthorized")
+	ErrForbidden          = Define("403", http.StatusForbidden, "error.forbidden", "Forbidden")
+	ErrNotFound           = Define("404", http.StatusNotFound, "error.not_found", "Not found")
+	ErrValidation         = Define("422", http.StatusUnprocessableEntity, "error.validation", "Validation failed")
+	ErrInternal           = Define("500", http.StatusInternalServerError, "error.internal", "Internal server error")
+	ErrServiceUnavailable = Define("503", http.StatusServiceUnavailable, "error.service_unavailable", "Service unavailable")
+)
@@ -0,0 +1,121 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestAppErrorWrapping(t *testing.T) {
+	c := NewCatalog()
+	closed := c.Define("ORDER_CLOSED", http.StatusConflict, "order.closed", "Order is closed")
+
+	err := fmt.Errorf("checkout: %w", closed.WithCause(io.ErrUnexpectedEOF).WithDetails(map[string]any{"order_id": 7}))
+
+	tests := []struct {
+		name   string
+		target error
+		want   bool
+	}{
+		{name: "same code", target: closed, want: true},
+		{name: "cause", target: io.ErrUnexpectedEOF, want: true},
+		{name: "other code", target: ErrNotFound, want: false},
+		{name: "nil app error", target: (*AppError)(nil), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var appErr *AppError
+	if !errors.As(err, &appErr) {
+		t.Fatal("errors.As() = false, want true")
+	}
+	if appErr.Status != http.StatusConflict || appErr.Details["order_id"] != 7 {
+		t.Errorf("errors.As() = %+v, want status and details", appErr)
+	}
+	if closed.Cause != nil || closed.Details != nil {
+		t.Error("With methods should not modify the declared error")
+	}
+}
+
+func TestAppErrorMessages(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *AppError
+		want string
+	}{
+		{name: "plain", err: ErrNotFound, want: "404: Not found"},
+		{name: "with cause", err: ErrInternal.WithCause(io.EOF), want: "500: Internal server error: EOF"},
+		{name: "with message", err: ErrNotFound.WithMessage("User not found"), want: "404: User not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithDetailsMerges(t *testing.T) {
+	err := ErrBadRequest.WithDetails(map[string]any{"a": 1}).WithDetails(map[string]any{"b": 2})
+	if len(err.Details) != 2 || err.Details["a"] != 1 || err.Details["b"] != 2 {
+		t.Errorf("WithDetails() = %v, want merged details", err.Details)
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	c := NewCatalog()
+	c.Define("B", http.StatusConflict, "b", "B")
+	c.Define("A", http.StatusGone, "a", "A")
+
+	if e, ok := c.Lookup("A"); !ok || e.Status != http.StatusGone {
+		t.Errorf("Lookup(A) = (%v, %v), want declared error", e, ok)
+	}
+	if _, ok := c.Lookup("C"); ok {
+		t.Error("Lookup(C) should return false")
+	}
+
+	all := c.All()
+	if len(all) != 2 || all[0].Code != "A" || all[1].Code != "B" {
+		t.Errorf("All() = %v, want [A B]", all)
+	}
+
+	if e, ok := Lookup("404"); !ok || e != ErrNotFound {
+		t.Errorf("Lookup(404) = (%v, %v), want ErrNotFound", e, ok)
+	}
+}
+
+func TestDefinePanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   string
+		status int
+	}{
+		{name: "duplicate code", code: "A", status: http.StatusConflict},
+		{name: "empty code", code: "", status: http.StatusConflict},
+		{name: "success status", code: "OK", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCatalog()
+			c.Define("A", http.StatusConflict, "a", "A")
+
+			defer func() {
+				if recover() == nil {
+					t.Error("Define() should panic")
+				}
+			}()
+			c.Define(tt.code, tt.status, "key", "message")
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Command breachdb builds an offline breached password dataset for package password.
+//
+// Usage:
+//
+//	breachdb -in passwords.txt -format plain -type bloom -out breached.bloom -fp 0.001
+//	breachdb -in pwned-passwords-sha1.txt -format sha1 -type range -out ./ranges
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ducconit/gobase/password"
+)
+
+func main() {
+	in := flag.String("in", "-", "input list, one entry per line (- for stdin)")
+	format := flag.String("format", "plain", "input format: plain or sha1")
+	kind := flag.String("type", "bloom", "dataset type: bloom or range")
+	out := flag.String("out", "", "output file (bloom) or directory (range)")
+	fp := flag.Float64("fp", 0.001, "bloom filter false positive rate")
+	flag.Parse()
+
+	if err := run(*in, *format, *kind, *out, *fp); err != nil {
+		fmt.Fprintln(os.Stderr, "breachdb:", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, format, kind, out string, fp float64) error {
+	if out == "" {
+		return fmt.Errorf("-out is required")
+	}
+
+	listFormat, err := password.ParseListFormat(format)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if in != "-" {
+		file, err := os.Open(in)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+	r = bufio.NewReader(r)
+
+	switch kind {
+	case "bloom":
+		f, err := password.BuildBloomFilter(r, listFormat, fp)
+		if err != nil {
+			return err
+		}
+		if err := password.SaveBloomFilter(out, f); err != nil {
+			return err
+		}
+		fmt.Printf("wrote %d entries to %s\n", f.Len(), out)
+		return nil
+	case "range":
+		if err := password.BuildRangeDir(r, listFormat, out); err != nil {
+			return err
+		}
+		fmt.Printf("wrote range files to %s\n", out)
+		return nil
+	}
+	return fmt.Errorf("unknown dataset type %q", kind)
+}
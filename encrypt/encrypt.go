@@ -0,0 +1,275 @@
+// Package encrypt provides authenticated symmetric encryption for data at rest,
+// such as stored OAuth tokens or PII columns, with key rotation.
+//
+// Ciphertexts are versioned envelopes that name the algorithm and key that produced them:
+//
+//	version (1 byte) | algorithm (1 byte) | key ID length (1 byte) | key ID | nonce | sealed data
+//
+// A Keyring encrypts with its primary key and decrypts with whichever key an envelope names,
+// so keys can be rotated while old ciphertexts stay readable until they are re-encrypted.
+package encrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// envelopeVersion is the current envelope format version.
+const envelopeVersion = 1
+
+// KeySize is the size of all keys in bytes (256 bits).
+const KeySize = 32
+
+// Errors returned when decrypting.
+var (
+	ErrInvalidCiphertext = errors.New("encrypt: invalid ciphertext")
+	ErrUnknownKey        = errors.New("encrypt: unknown key")
+	ErrDecrypt           = errors.New("encrypt: message authentication failed")
+)
+
+// Algorithm is an AEAD cipher.
+type Algorithm byte
+
+const (
+	// AES256GCM is AES-256 in Galois/Counter Mode with a random 96-bit nonce.
+	AES256GCM Algorithm = iota + 1
+
+	// XChaCha20Poly1305 is ChaCha20-Poly1305 with a random 192-bit nonce.
+	XChaCha20Poly1305
+)
+
+// String returns the algorithm name.
+func (a Algorithm) String() string {
+	switch a {
+	case AES256GCM:
+		return "AES-256-GCM"
+	case XChaCha20Poly1305:
+		return "XChaCha20-Poly1305"
+	}
+	return fmt.Sprintf("Algorithm(%d)", byte(a))
+}
+
+// Key is a named encryption key.
+type Key struct {
+	// ID names the key in envelopes. It must be 1 to 255 bytes long.
+	ID string
+
+	// Algorithm is the cipher the key is used with.
+	Algorithm Algorithm
+
+	// Secret is the KeySize bytes long key material.
+	Secret []byte
+}
+
+// GenerateKey creates a key with random key material.
+func GenerateKey(id string, alg Algorithm) (Key, error) {
+	secret := make([]byte, KeySize)
+	if _, err := rand.Read(secret); err != nil {
+		return Key{}, err
+	}
+
+	k := Key{ID: id, Algorithm: alg, Secret: secret}
+	if _, err := k.aead(); err != nil {
+		return Key{}, err
+	}
+	return k, nil
+}
+
+// aead returns the cipher of the key.
+func (k Key) aead() (cipher.AEAD, error) {
+	if len(k.ID) == 0 || len(k.ID) > math.MaxUint8 {
+		return nil, fmt.Errorf("encrypt: key id %q must be 1 to 255 bytes long", k.ID)
+	}
+	if len(k.Secret) != KeySize {
+		return nil, fmt.Errorf("encrypt: key %q must be %d bytes long", k.ID, KeySize)
+	}
+
+	switch k.Algorithm {
+	case AES256GCM:
+		block, err := aes.NewCipher(k.Secret)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	case XChaCha20Poly1305:
+		return chacha20poly1305.NewX(k.Secret)
+	}
+	return nil, fmt.Errorf("encrypt: unsupported algorithm %s", k.Algorithm)
+}
+
+// Keyring holds the keys used to encrypt and decrypt.
+type Keyring struct {
+	primary string
+	keys    map[string]keyEntry
+}
+
+// keyEntry is a key with its prepared cipher.
+type keyEntry struct {
+	key  Key
+	aead cipher.AEAD
+}
+
+// NewKeyring creates a keyring that encrypts with the key named primary.
+func NewKeyring(primary string, keys ...Key) (*Keyring, error) {
+	kr := &Keyring{
+		primary: primary,
+		keys:    make(map[string]keyEntry, len(keys)),
+	}
+
+	for _, k := range keys {
+		if _, ok := kr.keys[k.ID]; ok {
+			return nil, fmt.Errorf("encrypt: duplicate key id %q", k.ID)
+		}
+
+		aead, err := k.aead()
+		if err != nil {
+			return nil, err
+		}
+		k.Secret = append([]byte(nil), k.Secret...)
+		kr.keys[k.ID] = keyEntry{key: k, aead: aead}
+	}
+
+	if _, ok := kr.keys[primary]; !ok {
+		return nil, fmt.Errorf("%w: primary %q", ErrUnknownKey, primary)
+	}
+	return kr, nil
+}
+
+// Primary returns the ID of the key used for encryption.
+func (kr *Keyring) Primary() string {
+	return kr.primary
+}
+
+// Encrypt seals the plaintext with the primary key.
+// associatedData is authenticated but not encrypted, e.g. the row ID the value belongs to,
+// and must be passed again to Decrypt. It may be nil.
+func (kr *Keyring) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
+	entry := kr.keys[kr.primary]
+
+	header := make([]byte, 0, 3+len(entry.key.ID))
+	header = append(header, envelopeVersion, byte(entry.key.Algorithm), byte(len(entry.key.ID)))
+	header = append(header, entry.key.ID...)
+
+	nonce := make([]byte, entry.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(header)+len(nonce)+len(plaintext)+entry.aead.Overhead())
+	out = append(out, header...)
+	out = append(out, nonce...)
+	return entry.aead.Seal(out, nonce, plaintext, additionalData(header, associatedData)), nil
+}
+
+// Decrypt opens a ciphertext sealed with any key of the keyring.
+func (kr *Keyring) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	header, id, err := parseHeader(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, ok := kr.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, id)
+	}
+	if Algorithm(header[1]) != entry.key.Algorithm {
+		return nil, ErrInvalidCiphertext
+	}
+
+	rest := ciphertext[len(header):]
+	nonceSize := entry.aead.NonceSize()
+	if len(rest) < nonceSize+entry.aead.Overhead() {
+		return nil, ErrInvalidCiphertext
+	}
+
+	plaintext, err := entry.aead.Open(nil, rest[:nonceSize], rest[nonceSize:], additionalData(header, associatedData))
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// KeyID returns the ID of the key a ciphertext was sealed with.
+func KeyID(ciphertext []byte) (string, error) {
+	_, id, err := parseHeader(ciphertext)
+	return id, err
+}
+
+// NeedsReencrypt reports whether the ciphertext was sealed with a key other than the primary.
+func (kr *Keyring) NeedsReencrypt(ciphertext []byte) bool {
+	id, err := KeyID(ciphertext)
+	return err != nil || id != kr.primary
+}
+
+// Reencrypt decrypts the ciphertext with any key and seals it again with the primary key,
+// for rotating stored values after a new primary key is introduced.
+func (kr *Keyring) Reencrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	plaintext, err := kr.Decrypt(ciphertext, associatedData)
+	if err != nil {
+		return nil, err
+	}
+	return kr.Encrypt(plaintext, associatedData)
+}
+
+// EncryptString seals the plaintext and returns the envelope as unpadded base64url,
+// suitable for text columns.
+func (kr *Keyring) EncryptString(plaintext string, associatedData []byte) (string, error) {
+	ciphertext, err := kr.Encrypt([]byte(plaintext), associatedData)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// DecryptString opens an envelope produced by EncryptString.
+func (kr *Keyring) DecryptString(ciphertext string, associatedData []byte) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	plaintext, err := kr.Decrypt(raw, associatedData)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// ReencryptString re-encrypts an envelope produced by EncryptString with the primary key.
+func (kr *Keyring) ReencryptString(ciphertext string, associatedData []byte) (string, error) {
+	plaintext, err := kr.DecryptString(ciphertext, associatedData)
+	if err != nil {
+		return "", err
+	}
+	return kr.EncryptString(plaintext, associatedData)
+}
+
+// parseHeader splits off the envelope header and returns it with the key ID.
+func parseHeader(ciphertext []byte) ([]byte, string, error) {
+	if len(ciphertext) < 3 || ciphertext[0] != envelopeVersion {
+		return nil, "", ErrInvalidCiphertext
+	}
+
+	idLen := int(ciphertext[2])
+	if idLen == 0 || len(ciphertext) < 3+idLen {
+		return nil, "", ErrInvalidCiphertext
+	}
+
+	header := ciphertext[:3+idLen]
+	return header, string(header[3:]), nil
+}
+
+// additionalData binds the envelope header to the caller's associated data,
+// so the algorithm and key ID cannot be altered.
+func additionalData(header, associatedData []byte) []byte {
+	ad := make([]byte, 0, len(header)+len(associatedData))
+	ad = append(ad, header...)
+	return append(ad, associatedData...)
+}
@@ -0,0 +1,183 @@
+package encrypt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func newTestKey(t *testing.T, id string, alg Algorithm) Key {
+	t.Helper()
+	k, err := GenerateKey(id, alg)
+	if err != nil {
+		t.Fatalf("GenerateKey() failed: %v", err)
+	}
+	return k
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	for _, alg := range []Algorithm{AES256GCM, XChaCha20Poly1305} {
+		t.Run(alg.String(), func(t *testing.T) {
+			kr, err := NewKeyring("k1", newTestKey(t, "k1", alg))
+			if err != nil {
+				t.Fatalf("NewKeyring() failed: %v", err)
+			}
+
+			plaintext := []byte("oauth-refresh-token")
+			ad := []byte("user:42")
+
+			ciphertext, err := kr.Encrypt(plaintext, ad)
+			if err != nil {
+				t.Fatalf("Encrypt() failed: %v", err)
+			}
+			if bytes.Contains(ciphertext, plaintext) {
+				t.Error("Encrypt() output contains plaintext")
+			}
+
+			got, err := kr.Decrypt(ciphertext, ad)
+			if err != nil {
+				t.Fatalf("Decrypt() failed: %v", err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Errorf("Decrypt() = %q, want %q", got, plaintext)
+			}
+
+			again, _ := kr.Encrypt(plaintext, ad)
+			if bytes.Equal(again, ciphertext) {
+				t.Error("Encrypt() should use a fresh nonce")
+			}
+		})
+	}
+}
+
+func TestDecryptErrors(t *testing.T) {
+	kr, err := NewKeyring("k1", newTestKey(t, "k1", AES256GCM))
+	if err != nil {
+		t.Fatalf("NewKeyring() failed: %v", err)
+	}
+	other, err := NewKeyring("k2", newTestKey(t, "k2", AES256GCM))
+	if err != nil {
+		t.Fatalf("NewKeyring() failed: %v", err)
+	}
+
+	ciphertext, err := kr.Encrypt([]byte("secret"), []byte("user:42"))
+	if err != nil {
+		t.Fatalf("Encrypt() failed: %v", err)
+	}
+
+	tampered := bytes.Clone(ciphertext)
+	tampered[len(tampered)-1] ^= 1
+
+	wrongAlg := bytes.Clone(ciphertext)
+	wrongAlg[1] = byte(XChaCha20Poly1305)
+
+	tests := []struct {
+		name       string
+		keyring    *Keyring
+		ciphertext []byte
+		ad         []byte
+		wantErr    error
+	}{
+		{name: "tampered", keyring: kr, ciphertext: tampered, ad: []byte("user:42"), wantErr: ErrDecrypt},
+		{name: "wrong associated data", keyring: kr, ciphertext: ciphertext, ad: []byte("user:43"), wantErr: ErrDecrypt},
+		{name: "unknown key", keyring: other, ciphertext: ciphertext, ad: []byte("user:42"), wantErr: ErrUnknownKey},
+		{name: "wrong algorithm", keyring: kr, ciphertext: wrongAlg, ad: []byte("user:42"), wantErr: ErrInvalidCiphertext},
+		{name: "truncated", keyring: kr, ciphertext: ciphertext[:10], ad: []byte("user:42"), wantErr: ErrInvalidCiphertext},
+		{name: "empty", keyring: kr, ciphertext: nil, ad: nil, wantErr: ErrInvalidCiphertext},
+		{name: "wrong version", keyring: kr, ciphertext: append([]byte{9}, ciphertext[1:]...), ad: []byte("user:42"), wantErr: ErrInvalidCiphertext},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.keyring.Decrypt(tt.ciphertext, tt.ad); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Decrypt() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewKeyringErrors(t *testing.T) {
+	k1 := newTestKey(t, "k1", AES256GCM)
+
+	tests := []struct {
+		name    string
+		primary string
+		keys    []Key
+	}{
+		{name: "unknown primary", primary: "k2", keys: []Key{k1}},
+		{name: "duplicate id", primary: "k1", keys: []Key{k1, k1}},
+		{name: "short secret", primary: "k1", keys: []Key{{ID: "k1", Algorithm: AES256GCM, Secret: []byte("short")}}},
+		{name: "empty id", primary: "", keys: []Key{{ID: "", Algorithm: AES256GCM, Secret: k1.Secret}}},
+		{name: "unknown algorithm", primary: "k1", keys: []Key{{ID: "k1", Algorithm: 9, Secret: k1.Secret}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewKeyring(tt.primary, tt.keys...); err == nil {
+				t.Error("NewKeyring() should return error")
+			}
+		})
+	}
+}
+
+func TestRotation(t *testing.T) {
+	oldKey := newTestKey(t, "2024", AES256GCM)
+	newKey := newTestKey(t, "2025", XChaCha20Poly1305)
+
+	before, err := NewKeyring("2024", oldKey)
+	if err != nil {
+		t.Fatalf("NewKeyring() failed: %v", err)
+	}
+	after, err := NewKeyring("2025", oldKey, newKey)
+	if err != nil {
+		t.Fatalf("NewKeyring() failed: %v", err)
+	}
+
+	stored, err := before.EncryptString("4111 1111 1111 1111", nil)
+	if err != nil {
+		t.Fatalf("EncryptString() failed: %v", err)
+	}
+
+	got, err := after.DecryptString(stored, nil)
+	if err != nil || got != "4111 1111 1111 1111" {
+		t.Fatalf("DecryptString() = (%q, %v), want old ciphertext readable", got, err)
+	}
+
+	raw, _ := base64.RawURLEncoding.DecodeString(stored)
+	if !after.NeedsReencrypt(raw) {
+		t.Error("NeedsReencrypt() should return true for old key")
+	}
+
+	rotated, err := after.ReencryptString(stored, nil)
+	if err != nil {
+		t.Fatalf("ReencryptString() failed: %v", err)
+	}
+
+	raw, _ = base64.RawURLEncoding.DecodeString(rotated)
+	if id, _ := KeyID(raw); id != "2025" {
+		t.Errorf("KeyID() = %q, want 2025", id)
+	}
+	if after.NeedsReencrypt(raw) {
+		t.Error("NeedsReencrypt() should return false for primary key")
+	}
+
+	reencrypted, err := after.Reencrypt(raw, nil)
+	if err != nil {
+		t.Fatalf("Reencrypt() failed: %v", err)
+	}
+	if plaintext, err := after.Decrypt(reencrypted, nil); err != nil || string(plaintext) != "4111 1111 1111 1111" {
+		t.Errorf("Decrypt() = (%q, %v), want original plaintext", plaintext, err)
+	}
+}
+
+func TestDecryptStringInvalid(t *testing.T) {
+	kr, err := NewKeyring("k1", newTestKey(t, "k1", AES256GCM))
+	if err != nil {
+		t.Fatalf("NewKeyring() failed: %v", err)
+	}
+
+	if _, err := kr.DecryptString("not base64!", nil); !errors.Is(err, ErrInvalidCiphertext) {
+		t.Errorf("DecryptString() error = %v, want ErrInvalidCiphertext", err)
+	}
+}
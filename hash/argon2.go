@@ -0,0 +1,180 @@
+package hash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// argon2idPrefix is the PHC identifier prefix of Argon2id hashes.
+const argon2idPrefix = "$argon2id$"
+
+// ErrInvalidArgon2Hash is returned when a string is not a valid Argon2id PHC hash.
+var ErrInvalidArgon2Hash = errors.New("hash: invalid argon2id hash")
+
+// Argon2Params holds the tunable parameters of Argon2id.
+type Argon2Params struct {
+	// Memory is the amount of memory used in KiB.
+	Memory uint32
+
+	// Time is the number of passes over the memory.
+	Time uint32
+
+	// Parallelism is the number of threads used.
+	Parallelism uint8
+
+	// SaltLength is the length of the random salt in bytes.
+	SaltLength uint32
+
+	// KeyLength is the length of the derived key in bytes.
+	KeyLength uint32
+}
+
+// DefaultArgon2Params are the default Argon2id parameters (64 MiB, 3 passes, 2 threads).
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Time:        3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// NewArgon2 generates an Argon2id hash in PHC string format from the input string.
+// If no params are specified, DefaultArgon2Params is used.
+// The result looks like $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>.
+func NewArgon2(password string, params ...Argon2Params) (string, error) {
+	p := DefaultArgon2Params
+	if len(params) > 0 {
+		p = params[0]
+	}
+	if p.Memory == 0 || p.Time == 0 || p.Parallelism == 0 || p.SaltLength == 0 || p.KeyLength == 0 {
+		return "", errors.New("hash: argon2 parameters must be greater than 0")
+	}
+
+	salt := make([]byte, p.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Parallelism, p.KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, p.Memory, p.Time, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// CheckArgon2 verifies if the password matches the Argon2id hash.
+// Returns true if password is correct, false otherwise.
+func CheckArgon2(plainText, hash string) bool {
+	p, salt, key, err := decodeArgon2(hash)
+	if err != nil {
+		return false
+	}
+
+	other := argon2.IDKey([]byte(plainText), salt, p.Time, p.Memory, p.Parallelism, p.KeyLength)
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
+
+// IsArgon2Hash checks if the input string is a valid Argon2id hash.
+func IsArgon2Hash(input string) bool {
+	_, _, _, err := decodeArgon2(input)
+	return err == nil
+}
+
+// Argon2ParamsOf returns the parameters an Argon2id hash was created with.
+func Argon2ParamsOf(hash string) (Argon2Params, error) {
+	p, _, _, err := decodeArgon2(hash)
+	return p, err
+}
+
+// decodeArgon2 parses an Argon2id PHC string into its parameters, salt and key.
+func decodeArgon2(hash string) (Argon2Params, []byte, []byte, error) {
+	var p Argon2Params
+
+	if !strings.HasPrefix(hash, argon2idPrefix) {
+		return p, nil, nil, ErrInvalidArgon2Hash
+	}
+
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return p, nil, nil, ErrInvalidArgon2Hash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, ErrInvalidArgon2Hash
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Parallelism); err != nil {
+		return p, nil, nil, ErrInvalidArgon2Hash
+	}
+	if p.Memory == 0 || p.Time == 0 || p.Parallelism == 0 {
+		return p, nil, nil, ErrInvalidArgon2Hash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(salt) == 0 {
+		return p, nil, nil, ErrInvalidArgon2Hash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, ErrInvalidArgon2Hash
+	}
+
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(key))
+
+	return p, salt, key, nil
+}
+
+// Argon2idHasher is the Argon2id Hasher.
+type Argon2idHasher struct {
+	// Params are the Argon2id parameters. The zero value means DefaultArgon2Params.
+	Params Argon2Params
+}
+
+// Name returns "argon2id".
+func (Argon2idHasher) Name() string {
+	return AlgArgon2id
+}
+
+// Hash generates an Argon2id hash from the password.
+func (h Argon2idHasher) Hash(password string) (string, error) {
+	if h.Params == (Argon2Params{}) {
+		return NewArgon2(password)
+	}
+	return NewArgon2(password, h.Params)
+}
+
+// Verify reports whether the password matches the Argon2id hash.
+func (Argon2idHasher) Verify(password, hash string) bool {
+	return CheckArgon2(password, hash)
+}
+
+// IsHash reports whether the input is a valid Argon2id hash.
+func (Argon2idHasher) IsHash(input string) bool {
+	return IsArgon2Hash(input)
+}
+
+// NeedsRehash reports whether the Argon2id hash was created with different parameters.
+func (h Argon2idHasher) NeedsRehash(hash string) bool {
+	p, err := Argon2ParamsOf(hash)
+	if err != nil {
+		return true
+	}
+
+	want := h.Params
+	if want == (Argon2Params{}) {
+		want = DefaultArgon2Params
+	}
+	return p != want
+}
@@ -0,0 +1,127 @@
+package hash
+
+import (
+	"strings"
+	"testing"
+)
+
+// testArgon2Params keeps the tests fast while exercising the full code path.
+var testArgon2Params = Argon2Params{
+	Memory:      8 * 1024,
+	Time:        1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestNewArgon2(t *testing.T) {
+	password := "testpassword123"
+
+	hash, err := NewArgon2(password, testArgon2Params)
+	if err != nil {
+		t.Fatalf("NewArgon2() failed: %v", err)
+	}
+
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=8192,t=1,p=1$") {
+		t.Errorf("NewArgon2() = %s, want PHC string with argon2id prefix", hash)
+	}
+
+	if !CheckArgon2(password, hash) {
+		t.Error("CheckArgon2() should return true for correct password")
+	}
+
+	if CheckArgon2("wrongpassword", hash) {
+		t.Error("CheckArgon2() should return false for incorrect password")
+	}
+}
+
+func TestNewArgon2InvalidParams(t *testing.T) {
+	_, err := NewArgon2("password", Argon2Params{})
+	if err == nil {
+		t.Error("NewArgon2() should return error with zero params")
+	}
+}
+
+func TestNewArgon2LongPassword(t *testing.T) {
+	longPassword := strings.Repeat("a", 200)
+
+	hash, err := NewArgon2(longPassword, testArgon2Params)
+	if err != nil {
+		t.Fatalf("NewArgon2() failed: %v", err)
+	}
+
+	if !CheckArgon2(longPassword, hash) {
+		t.Error("CheckArgon2() should return true for long password")
+	}
+
+	if CheckArgon2(longPassword[:199], hash) {
+		t.Error("CheckArgon2() should not truncate passwords")
+	}
+}
+
+func TestArgon2ParamsOf(t *testing.T) {
+	hash, err := NewArgon2("password", testArgon2Params)
+	if err != nil {
+		t.Fatalf("NewArgon2() failed: %v", err)
+	}
+
+	got, err := Argon2ParamsOf(hash)
+	if err != nil {
+		t.Fatalf("Argon2ParamsOf() failed: %v", err)
+	}
+
+	if got != testArgon2Params {
+		t.Errorf("Argon2ParamsOf() = %+v, want %+v", got, testArgon2Params)
+	}
+}
+
+func TestIsArgon2Hash(t *testing.T) {
+	hash, err := NewArgon2("password", testArgon2Params)
+	if err != nil {
+		t.Fatalf("NewArgon2() failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "valid hash", input: hash, want: true},
+		{name: "empty string", input: "", want: false},
+		{name: "bcrypt hash", input: Make("password"), want: false},
+		{name: "wrong version", input: strings.Replace(hash, "v=19", "v=16", 1), want: false},
+		{name: "missing parts", input: "$argon2id$v=19$m=8192,t=1,p=1$c2FsdA", want: false},
+		{name: "zero memory", input: strings.Replace(hash, "m=8192", "m=0", 1), want: false},
+		{name: "invalid salt encoding", input: "$argon2id$v=19$m=8192,t=1,p=1$!!!$c2FsdA", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsArgon2Hash(tt.input); got != tt.want {
+				t.Errorf("IsArgon2Hash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMixedHashes(t *testing.T) {
+	password := "testpassword123"
+
+	argonHash, err := NewArgon2(password, testArgon2Params)
+	if err != nil {
+		t.Fatalf("NewArgon2() failed: %v", err)
+	}
+	bcryptHash := Make(password)
+
+	for _, hash := range []string{argonHash, bcryptHash} {
+		if !Check(password, hash) {
+			t.Errorf("Check() should return true for %s", hash)
+		}
+		if Check("wrongpassword", hash) {
+			t.Errorf("Check() should return false for %s", hash)
+		}
+		if !IsHash(hash) {
+			t.Errorf("IsHash() should return true for %s", hash)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package hash
+
+import (
+	"crypto/rand"
+	"errors"
+	"math"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MinBcryptCost is the lowest bcrypt cost Calibrate returns.
+const MinBcryptCost = 10
+
+// MinArgon2Params are the lowest Argon2id parameters Calibrate returns (19 MiB, 2 passes, 1 thread).
+var MinArgon2Params = Argon2Params{
+	Memory:      19 * 1024,
+	Time:        2,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// calibrationRuns is the number of measurements per algorithm; the fastest one is used.
+const calibrationRuns = 2
+
+// Calibration holds hashing parameters tuned to a target latency on the current machine.
+type Calibration struct {
+	// BcryptCost is the bcrypt cost closest to the target.
+	BcryptCost int
+
+	// Argon2 holds the Argon2id parameters closest to the target.
+	Argon2 Argon2Params
+}
+
+// Apply returns a copy of the policy using the calibrated parameters.
+func (c Calibration) Apply(p Policy) Policy {
+	p.BcryptCost = c.BcryptCost
+	p.Argon2 = c.Argon2
+	return p
+}
+
+// Calibrate benchmarks the machine and returns the bcrypt cost and Argon2id parameters
+// whose hashing time lands closest to target, e.g. 250ms.
+// The results never go below MinBcryptCost and MinArgon2Params.
+// It is meant to run once at startup, as it hashes for a few hundred milliseconds.
+func Calibrate(target time.Duration) (Calibration, error) {
+	if target <= 0 {
+		return Calibration{}, errors.New("hash: calibration target must be greater than 0")
+	}
+
+	cost, err := calibrateBcrypt(target)
+	if err != nil {
+		return Calibration{}, err
+	}
+
+	return Calibration{
+		BcryptCost: cost,
+		Argon2:     calibrateArgon2(target),
+	}, nil
+}
+
+// calibrateBcrypt measures MinBcryptCost and extrapolates, as each extra cost doubles the time.
+func calibrateBcrypt(target time.Duration) (int, error) {
+	var elapsed time.Duration
+	for i := 0; i < calibrationRuns; i++ {
+		start := time.Now()
+		if _, err := bcrypt.GenerateFromPassword([]byte("calibrate"), MinBcryptCost); err != nil {
+			return 0, err
+		}
+		if d := time.Since(start); i == 0 || d < elapsed {
+			elapsed = d
+		}
+	}
+
+	cost := MinBcryptCost + int(math.Round(math.Log2(float64(target)/float64(elapsed))))
+	return min(max(cost, MinBcryptCost), bcrypt.MaxCost), nil
+}
+
+// calibrateArgon2 measures one pass over MinArgon2Params.Memory and extrapolates,
+// as Argon2id time grows linearly with both memory and passes.
+// It prefers DefaultArgon2Params.Memory and adds passes; when even MinArgon2Params.Time
+// passes are too slow at that memory, it lowers the memory instead.
+func calibrateArgon2(target time.Duration) Argon2Params {
+	p := DefaultArgon2Params
+	p.Parallelism = MinArgon2Params.Parallelism
+
+	salt := make([]byte, p.SaltLength)
+	_, _ = rand.Read(salt)
+
+	var elapsed time.Duration
+	for i := 0; i < calibrationRuns; i++ {
+		start := time.Now()
+		argon2.IDKey([]byte("calibrate"), salt, 1, MinArgon2Params.Memory, p.Parallelism, p.KeyLength)
+		if d := time.Since(start); i == 0 || d < elapsed {
+			elapsed = d
+		}
+	}
+
+	// Time of one pass over one KiB.
+	perKiB := float64(elapsed) / float64(MinArgon2Params.Memory)
+
+	passes := math.Round(float64(target) / (perKiB * float64(p.Memory)))
+	if passes >= float64(MinArgon2Params.Time) {
+		p.Time = uint32(min(passes, math.MaxUint32))
+		return p
+	}
+
+	p.Time = MinArgon2Params.Time
+	memory := float64(target) / (perKiB * float64(p.Time))
+	p.Memory = uint32(min(max(memory, float64(MinArgon2Params.Memory)), float64(DefaultArgon2Params.Memory)))
+	return p
+}
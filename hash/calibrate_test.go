@@ -0,0 +1,49 @@
+package hash
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalibrateMinimums(t *testing.T) {
+	c, err := Calibrate(time.Microsecond)
+	if err != nil {
+		t.Fatalf("Calibrate() failed: %v", err)
+	}
+
+	if c.BcryptCost != MinBcryptCost {
+		t.Errorf("Calibrate() BcryptCost = %d, want %d", c.BcryptCost, MinBcryptCost)
+	}
+	if c.Argon2.Memory != MinArgon2Params.Memory || c.Argon2.Time != MinArgon2Params.Time {
+		t.Errorf("Calibrate() Argon2 = %+v, want minimums %+v", c.Argon2, MinArgon2Params)
+	}
+}
+
+func TestCalibrateLongTarget(t *testing.T) {
+	c, err := Calibrate(time.Hour)
+	if err != nil {
+		t.Fatalf("Calibrate() failed: %v", err)
+	}
+
+	if c.BcryptCost <= MinBcryptCost {
+		t.Errorf("Calibrate() BcryptCost = %d, want above %d", c.BcryptCost, MinBcryptCost)
+	}
+	if c.Argon2.Memory != DefaultArgon2Params.Memory || c.Argon2.Time <= MinArgon2Params.Time {
+		t.Errorf("Calibrate() Argon2 = %+v, want default memory and more passes", c.Argon2)
+	}
+}
+
+func TestCalibrateInvalidTarget(t *testing.T) {
+	if _, err := Calibrate(0); err == nil {
+		t.Error("Calibrate() should return error for zero target")
+	}
+}
+
+func TestCalibrationApply(t *testing.T) {
+	c := Calibration{BcryptCost: 13, Argon2: MinArgon2Params}
+	p := c.Apply(Policy{Algorithm: AlgArgon2id})
+
+	if p.Algorithm != AlgArgon2id || p.BcryptCost != 13 || p.Argon2 != MinArgon2Params {
+		t.Errorf("Apply() = %+v, want calibrated policy", p)
+	}
+}
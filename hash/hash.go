@@ -1,4 +1,11 @@
-// Package hash provides bcrypt hashing utilities.
+// Package hash provides password hashing utilities backed by bcrypt, Argon2id, scrypt and PBKDF2.
+//
+// New and Make produce bcrypt hashes, which are limited to 72-byte passwords;
+// NewBcryptSHA512 pre-hashes the password to lift that limit. When DefaultPolicy has peppers,
+// New and Make pepper the password first, like DefaultPolicy.Hash.
+//
+// Stored hashes are self-describing: Check and IsHash pick the algorithm from the hash prefix
+// using the hashers in the registry, so hashes of different algorithms can be verified side by side.
 package hash
 
 import "golang.org/x/crypto/bcrypt"
@@ -10,13 +17,26 @@ const (
 
 // New generates a bcrypt hash from the input string with optional custom rounds.
 // If no rounds are specified, DefaultRounds is used.
+// If DefaultPolicy has peppers, the password is peppered with the current key first.
 // Returns the hashed string or an error if hashing fails.
 func New(password string, rounds ...int) (string, error) {
-	cost := DefaultRounds
+	cost := bcryptCost(rounds)
+	if DefaultPolicy.Peppers != nil {
+		return DefaultPolicy.Peppers.hash(BcryptHasher{Cost: cost}, password)
+	}
+	return bcryptHash(password, cost)
+}
+
+// bcryptCost returns the optional rounds argument, or DefaultRounds.
+func bcryptCost(rounds []int) int {
 	if len(rounds) > 0 {
-		cost = rounds[0]
+		return rounds[0]
 	}
+	return DefaultRounds
+}
 
+// bcryptHash generates a plain bcrypt hash, without pepper.
+func bcryptHash(password string, cost int) (string, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), cost)
 	if err != nil {
 		return "", err
@@ -36,14 +56,66 @@ func Make(password string) string {
 	return hashedPassword
 }
 
-// Check verifies if the password matches the bcrypt hash.
+// Check verifies if the password matches the hash, whatever registered algorithm created it.
+// Peppered hashes are verified with the peppers of DefaultPolicy.
 // Returns true if password is correct, false otherwise.
 func Check(plainText, hash string) bool {
-	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(plainText)) == nil
+	return DefaultPolicy.Check(plainText, hash)
 }
 
-// IsHash checks if the input string is a valid bcrypt hash.
+// IsHash checks if the input string is a valid hash of any registered algorithm.
 func IsHash(input string) bool {
+	if _, inner, ok := splitPepper(input); ok {
+		input = inner
+	}
+
+	h, ok := Lookup(input)
+	if !ok {
+		return false
+	}
+	return h.IsHash(input)
+}
+
+// BcryptHasher is the bcrypt Hasher.
+type BcryptHasher struct {
+	// Cost is the bcrypt cost parameter. Zero means DefaultRounds.
+	Cost int
+}
+
+// Name returns "bcrypt".
+func (BcryptHasher) Name() string {
+	return AlgBcrypt
+}
+
+// Hash generates a bcrypt hash from the password.
+func (h BcryptHasher) Hash(password string) (string, error) {
+	if h.Cost == 0 {
+		return bcryptHash(password, DefaultRounds)
+	}
+	return bcryptHash(password, h.Cost)
+}
+
+// Verify reports whether the password matches the bcrypt hash.
+func (BcryptHasher) Verify(password, hash string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// IsHash reports whether the input is a valid bcrypt hash.
+func (BcryptHasher) IsHash(input string) bool {
 	_, err := bcrypt.Cost([]byte(input))
 	return err == nil
 }
+
+// NeedsRehash reports whether the bcrypt hash was created with a different cost.
+func (h BcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return true
+	}
+
+	want := h.Cost
+	if want == 0 {
+		want = DefaultRounds
+	}
+	return cost != want
+}
@@ -0,0 +1,124 @@
+package hash
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Algorithm names of the legacy wrapped hashers.
+// A wrapped hash is the bcrypt hash of the hex digest a legacy system stored,
+// e.g. bcrypt(md5(password)), so imported users keep their passwords.
+const (
+	AlgLegacyMD5    = "legacy-md5"
+	AlgLegacySHA1   = "legacy-sha1"
+	AlgLegacySHA256 = "legacy-sha256"
+)
+
+// legacyDigests maps legacy algorithm names to the unsalted digest they wrap.
+var legacyDigests = map[string]func([]byte) []byte{
+	AlgLegacyMD5: func(b []byte) []byte {
+		sum := md5.Sum(b)
+		return sum[:]
+	},
+	AlgLegacySHA1: func(b []byte) []byte {
+		sum := sha1.Sum(b)
+		return sum[:]
+	},
+	AlgLegacySHA256: func(b []byte) []byte {
+		sum := sha256.Sum256(b)
+		return sum[:]
+	},
+}
+
+// legacyPrefix returns the marker of a legacy algorithm, e.g. $legacy-md5$.
+// Like the pre-hashed bcrypt marker, it replaces the leading "$" of the bcrypt hash.
+func legacyPrefix(alg string) string {
+	return "$" + alg + "$"
+}
+
+// WrapLegacy wraps a hex digest stored by a legacy system in bcrypt with optional custom rounds,
+// e.g. WrapLegacy(AlgLegacyMD5, "5f4dcc3b5aa765d61d8327deb882cf99").
+// The result verifies with Check against the original password and is upgraded by CheckAndUpgrade.
+func WrapLegacy(alg string, digest string, rounds ...int) (string, error) {
+	sum, ok := legacyDigests[alg]
+	if !ok {
+		return "", fmt.Errorf("hash: unknown legacy algorithm %q", alg)
+	}
+
+	raw, err := hex.DecodeString(digest)
+	if err != nil || len(raw) != len(sum(nil)) {
+		return "", fmt.Errorf("hash: invalid %s digest", alg)
+	}
+
+	inner, err := bcryptHash(hex.EncodeToString(raw), bcryptCost(rounds))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(legacyPrefix(alg), "$") + inner, nil
+}
+
+// LegacyHasher is the Hasher of bcrypt wrapped legacy digests.
+// It exists to migrate imported users; new hashes should use a modern algorithm.
+type LegacyHasher struct {
+	// Algorithm is one of AlgLegacyMD5, AlgLegacySHA1 or AlgLegacySHA256.
+	Algorithm string
+
+	// Cost is the bcrypt cost parameter. Zero means DefaultRounds.
+	Cost int
+}
+
+// Name returns the legacy algorithm name, e.g. "legacy-md5".
+func (h LegacyHasher) Name() string {
+	return h.Algorithm
+}
+
+// Hash generates a wrapped hash from the password by applying the legacy digest first.
+func (h LegacyHasher) Hash(password string) (string, error) {
+	sum, ok := legacyDigests[h.Algorithm]
+	if !ok {
+		return "", fmt.Errorf("hash: unknown legacy algorithm %q", h.Algorithm)
+	}
+
+	digest := hex.EncodeToString(sum([]byte(password)))
+	if h.Cost == 0 {
+		return WrapLegacy(h.Algorithm, digest)
+	}
+	return WrapLegacy(h.Algorithm, digest, h.Cost)
+}
+
+// Verify reports whether the password matches the wrapped hash.
+func (h LegacyHasher) Verify(password, hash string) bool {
+	sum, ok := legacyDigests[h.Algorithm]
+	if !ok {
+		return false
+	}
+
+	inner, ok := h.unwrap(hash)
+	if !ok {
+		return false
+	}
+
+	digest := hex.EncodeToString(sum([]byte(password)))
+	return bcrypt.CompareHashAndPassword([]byte(inner), []byte(digest)) == nil
+}
+
+// IsHash reports whether the input is a valid wrapped hash.
+func (h LegacyHasher) IsHash(input string) bool {
+	inner, ok := h.unwrap(input)
+	return ok && BcryptHasher{}.IsHash(inner)
+}
+
+// unwrap returns the plain bcrypt hash inside a wrapped hash.
+func (h LegacyHasher) unwrap(hash string) (string, bool) {
+	prefix := legacyPrefix(h.Algorithm)
+	if !strings.HasPrefix(hash, prefix) {
+		return "", false
+	}
+	return hash[len(prefix)-1:], true
+}
@@ -0,0 +1,92 @@
+package hash
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrapLegacy(t *testing.T) {
+	tests := []struct {
+		name   string
+		alg    string
+		digest string
+	}{
+		{name: "md5", alg: AlgLegacyMD5, digest: "5f4dcc3b5aa765d61d8327deb882cf99"},
+		{name: "sha1", alg: AlgLegacySHA1, digest: "5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8"},
+		{name: "sha256", alg: AlgLegacySHA256, digest: "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"},
+		{name: "uppercase md5", alg: AlgLegacyMD5, digest: "5F4DCC3B5AA765D61D8327DEB882CF99"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := WrapLegacy(tt.alg, tt.digest, 4)
+			if err != nil {
+				t.Fatalf("WrapLegacy() failed: %v", err)
+			}
+
+			if !strings.HasPrefix(hash, "$"+tt.alg+"$2a$04$") {
+				t.Errorf("WrapLegacy() = %s, want %s marker", hash, tt.alg)
+			}
+			if Algorithm(hash) != tt.alg {
+				t.Errorf("Algorithm() = %q, want %q", Algorithm(hash), tt.alg)
+			}
+			if !IsHash(hash) {
+				t.Error("IsHash() should return true for wrapped hash")
+			}
+			if !Check("password", hash) {
+				t.Error("Check() should return true for correct password")
+			}
+			if Check("wrongpassword", hash) {
+				t.Error("Check() should return false for incorrect password")
+			}
+		})
+	}
+}
+
+func TestWrapLegacyInvalid(t *testing.T) {
+	if _, err := WrapLegacy("legacy-crc32", "deadbeef"); err == nil {
+		t.Error("WrapLegacy() should return error for unknown algorithm")
+	}
+	if _, err := WrapLegacy(AlgLegacyMD5, "not hex"); err == nil {
+		t.Error("WrapLegacy() should return error for invalid digest")
+	}
+	if _, err := WrapLegacy(AlgLegacyMD5, "5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8"); err == nil {
+		t.Error("WrapLegacy() should return error for digest of wrong length")
+	}
+}
+
+func TestLegacyHasher(t *testing.T) {
+	h := LegacyHasher{Algorithm: AlgLegacySHA256, Cost: 4}
+
+	hash, err := h.Hash("password")
+	if err != nil {
+		t.Fatalf("Hash() failed: %v", err)
+	}
+
+	if !Check("password", hash) {
+		t.Error("Check() should return true for correct password")
+	}
+	if (LegacyHasher{Algorithm: AlgLegacyMD5}).Verify("password", hash) {
+		t.Error("Verify() should return false for hash of another legacy algorithm")
+	}
+}
+
+func TestCheckAndUpgradeLegacy(t *testing.T) {
+	withDefaultPolicy(t, Policy{Algorithm: AlgArgon2id, Argon2: testArgon2Params})
+
+	hash, err := WrapLegacy(AlgLegacyMD5, "5f4dcc3b5aa765d61d8327deb882cf99", 4)
+	if err != nil {
+		t.Fatalf("WrapLegacy() failed: %v", err)
+	}
+
+	ok, newHash, err := CheckAndUpgrade("password", hash)
+	if err != nil || !ok {
+		t.Fatalf("CheckAndUpgrade() = (%v, %q, %v), want ok", ok, newHash, err)
+	}
+	if Algorithm(newHash) != AlgArgon2id {
+		t.Errorf("CheckAndUpgrade() newHash = %q, want argon2id hash", newHash)
+	}
+	if !Check("password", newHash) {
+		t.Error("upgraded hash should verify")
+	}
+}
@@ -0,0 +1,171 @@
+package hash
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pbkdf2SHA256Prefix is the identifier prefix of PBKDF2-SHA256 hashes.
+const pbkdf2SHA256Prefix = "$pbkdf2-sha256$"
+
+// ErrInvalidPBKDF2Hash is returned when a string is not a valid PBKDF2-SHA256 hash.
+var ErrInvalidPBKDF2Hash = errors.New("hash: invalid pbkdf2-sha256 hash")
+
+// ab64Encoding is the passlib "adapted base64" alphabet, which uses "." instead of "+".
+var ab64Encoding = base64.NewEncoding("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789./").
+	WithPadding(base64.NoPadding)
+
+// PBKDF2Params holds the tunable parameters of PBKDF2-SHA256.
+type PBKDF2Params struct {
+	// Iterations is the number of PBKDF2 rounds.
+	Iterations int
+
+	// SaltLength is the length of the random salt in bytes.
+	SaltLength int
+
+	// KeyLength is the length of the derived key in bytes.
+	KeyLength int
+}
+
+// DefaultPBKDF2Params are the default PBKDF2-SHA256 parameters (600000 iterations).
+var DefaultPBKDF2Params = PBKDF2Params{
+	Iterations: 600000,
+	SaltLength: 16,
+	KeyLength:  32,
+}
+
+// NewPBKDF2 generates a PBKDF2-SHA256 hash from the input string.
+// If no params are specified, DefaultPBKDF2Params is used.
+// The result uses the passlib format $pbkdf2-sha256$<iterations>$<salt>$<hash>.
+func NewPBKDF2(password string, params ...PBKDF2Params) (string, error) {
+	p := DefaultPBKDF2Params
+	if len(params) > 0 {
+		p = params[0]
+	}
+	if p.Iterations <= 0 || p.SaltLength <= 0 || p.KeyLength <= 0 {
+		return "", errors.New("hash: pbkdf2 parameters must be greater than 0")
+	}
+
+	salt := make([]byte, p.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, p.Iterations, p.KeyLength)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%d$%s$%s",
+		pbkdf2SHA256Prefix, p.Iterations,
+		ab64Encoding.EncodeToString(salt),
+		ab64Encoding.EncodeToString(key),
+	), nil
+}
+
+// CheckPBKDF2 verifies if the password matches the PBKDF2-SHA256 hash.
+// Returns true if password is correct, false otherwise.
+func CheckPBKDF2(plainText, hash string) bool {
+	p, salt, key, err := decodePBKDF2(hash)
+	if err != nil {
+		return false
+	}
+
+	other, err := pbkdf2.Key(sha256.New, plainText, salt, p.Iterations, p.KeyLength)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
+
+// IsPBKDF2Hash checks if the input string is a valid PBKDF2-SHA256 hash.
+func IsPBKDF2Hash(input string) bool {
+	_, _, _, err := decodePBKDF2(input)
+	return err == nil
+}
+
+// decodePBKDF2 parses a PBKDF2-SHA256 hash into its parameters, salt and key.
+func decodePBKDF2(hash string) (PBKDF2Params, []byte, []byte, error) {
+	var p PBKDF2Params
+
+	if !strings.HasPrefix(hash, pbkdf2SHA256Prefix) {
+		return p, nil, nil, ErrInvalidPBKDF2Hash
+	}
+
+	// "", "pbkdf2-sha256", iterations, salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 5 {
+		return p, nil, nil, ErrInvalidPBKDF2Hash
+	}
+
+	iterations, err := strconv.Atoi(parts[2])
+	if err != nil || iterations <= 0 {
+		return p, nil, nil, ErrInvalidPBKDF2Hash
+	}
+
+	salt, err := ab64Encoding.DecodeString(parts[3])
+	if err != nil || len(salt) == 0 {
+		return p, nil, nil, ErrInvalidPBKDF2Hash
+	}
+
+	key, err := ab64Encoding.DecodeString(parts[4])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, ErrInvalidPBKDF2Hash
+	}
+
+	p.Iterations = iterations
+	p.SaltLength = len(salt)
+	p.KeyLength = len(key)
+
+	return p, salt, key, nil
+}
+
+// PBKDF2Hasher is the PBKDF2-SHA256 Hasher.
+type PBKDF2Hasher struct {
+	// Params are the PBKDF2 parameters. The zero value means DefaultPBKDF2Params.
+	Params PBKDF2Params
+}
+
+// Name returns "pbkdf2-sha256".
+func (PBKDF2Hasher) Name() string {
+	return AlgPBKDF2SHA256
+}
+
+// Hash generates a PBKDF2-SHA256 hash from the password.
+func (h PBKDF2Hasher) Hash(password string) (string, error) {
+	if h.Params == (PBKDF2Params{}) {
+		return NewPBKDF2(password)
+	}
+	return NewPBKDF2(password, h.Params)
+}
+
+// Verify reports whether the password matches the PBKDF2-SHA256 hash.
+func (PBKDF2Hasher) Verify(password, hash string) bool {
+	return CheckPBKDF2(password, hash)
+}
+
+// IsHash reports whether the input is a valid PBKDF2-SHA256 hash.
+func (PBKDF2Hasher) IsHash(input string) bool {
+	return IsPBKDF2Hash(input)
+}
+
+// NeedsRehash reports whether the PBKDF2-SHA256 hash was created with different parameters.
+func (h PBKDF2Hasher) NeedsRehash(hash string) bool {
+	p, _, _, err := decodePBKDF2(hash)
+	if err != nil {
+		return true
+	}
+
+	want := h.Params
+	if want == (PBKDF2Params{}) {
+		want = DefaultPBKDF2Params
+	}
+	return p != want
+}
@@ -0,0 +1,70 @@
+package hash
+
+import (
+	"strings"
+	"testing"
+)
+
+// testPBKDF2Params keeps the tests fast while exercising the full code path.
+var testPBKDF2Params = PBKDF2Params{
+	Iterations: 1000,
+	SaltLength: 16,
+	KeyLength:  32,
+}
+
+func TestNewPBKDF2(t *testing.T) {
+	password := "testpassword123"
+
+	hash, err := NewPBKDF2(password, testPBKDF2Params)
+	if err != nil {
+		t.Fatalf("NewPBKDF2() failed: %v", err)
+	}
+
+	if !strings.HasPrefix(hash, "$pbkdf2-sha256$1000$") {
+		t.Errorf("NewPBKDF2() = %s, want pbkdf2-sha256 prefix", hash)
+	}
+
+	if !CheckPBKDF2(password, hash) {
+		t.Error("CheckPBKDF2() should return true for correct password")
+	}
+
+	if CheckPBKDF2("wrongpassword", hash) {
+		t.Error("CheckPBKDF2() should return false for incorrect password")
+	}
+}
+
+func TestCheckPBKDF2PasslibVector(t *testing.T) {
+	// Generated by passlib.hash.pbkdf2_sha256.
+	hash := "$pbkdf2-sha256$6400$0ZrzXitFSGltTQnBWOsdAw$Y11AchqV4b0sUisdZd0Xr97KWoymNE0LNNrnEgY4H9M"
+
+	if !CheckPBKDF2("password", hash) {
+		t.Error("CheckPBKDF2() should verify passlib hash")
+	}
+}
+
+func TestIsPBKDF2Hash(t *testing.T) {
+	hash, err := NewPBKDF2("password", testPBKDF2Params)
+	if err != nil {
+		t.Fatalf("NewPBKDF2() failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "valid hash", input: hash, want: true},
+		{name: "empty string", input: "", want: false},
+		{name: "zero iterations", input: strings.Replace(hash, "$1000$", "$0$", 1), want: false},
+		{name: "non numeric iterations", input: strings.Replace(hash, "$1000$", "$abc$", 1), want: false},
+		{name: "missing parts", input: "$pbkdf2-sha256$1000$c2FsdA", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPBKDF2Hash(tt.input); got != tt.want {
+				t.Errorf("IsPBKDF2Hash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package hash
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// pepperPrefix marks hashes whose password was peppered before hashing.
+// A peppered hash looks like $pepper$<key id><inner hash>, e.g. $pepper$k1$2a$12$...
+const pepperPrefix = "$pepper$"
+
+// ErrUnknownPepper is returned when a pepper key ID is not part of the keyring.
+var ErrUnknownPepper = errors.New("hash: unknown pepper key")
+
+// Peppers is a keyring of server-side secrets mixed into passwords with HMAC-SHA256 before hashing.
+// New hashes use the current key; the other keys are retired and only used for verification.
+type Peppers struct {
+	current string
+	keys    map[string][]byte
+}
+
+// NewPeppers creates a keyring from key IDs to secrets, using current for new hashes.
+// Key IDs must be non-empty and must not contain "$".
+func NewPeppers(current string, keys map[string][]byte) (*Peppers, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPepper, current)
+	}
+
+	p := &Peppers{
+		current: current,
+		keys:    make(map[string][]byte, len(keys)),
+	}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, "$") {
+			return nil, fmt.Errorf("hash: invalid pepper key id %q", id)
+		}
+		if len(key) == 0 {
+			return nil, fmt.Errorf("hash: empty pepper key %q", id)
+		}
+		p.keys[id] = append([]byte(nil), key...)
+	}
+
+	return p, nil
+}
+
+// Current returns the ID of the key used for new hashes.
+func (p *Peppers) Current() string {
+	return p.current
+}
+
+// Apply mixes the pepper with the given key ID into the password.
+// The result is the base64 encoded HMAC-SHA256 of the password.
+func (p *Peppers) Apply(id, password string) (string, error) {
+	key, ok := p.keys[id]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownPepper, id)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(password))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// hash peppers the password with the current key and hashes it with h.
+func (p *Peppers) hash(h Hasher, password string) (string, error) {
+	peppered, err := p.Apply(p.current, password)
+	if err != nil {
+		return "", err
+	}
+
+	inner, err := h.Hash(peppered)
+	if err != nil {
+		return "", err
+	}
+	return pepperPrefix + p.current + inner, nil
+}
+
+// verify checks the password against a peppered hash using the key it names.
+func (p *Peppers) verify(password, hash string) bool {
+	id, inner, ok := splitPepper(hash)
+	if !ok {
+		return false
+	}
+
+	peppered, err := p.Apply(id, password)
+	if err != nil {
+		return false
+	}
+
+	h, ok := Lookup(inner)
+	if !ok {
+		return false
+	}
+	return h.Verify(peppered, inner)
+}
+
+// IsPeppered reports whether the hash was created from a peppered password.
+func IsPeppered(hash string) bool {
+	_, _, ok := splitPepper(hash)
+	return ok
+}
+
+// PepperID returns the pepper key ID of a peppered hash.
+func PepperID(hash string) (string, bool) {
+	id, _, ok := splitPepper(hash)
+	return id, ok
+}
+
+// splitPepper splits a peppered hash into its key ID and inner hash.
+func splitPepper(hash string) (id string, inner string, ok bool) {
+	rest, found := strings.CutPrefix(hash, pepperPrefix)
+	if !found {
+		return "", "", false
+	}
+
+	i := strings.IndexByte(rest, '$')
+	if i <= 0 {
+		return "", "", false
+	}
+	return rest[:i], rest[i:], true
+}
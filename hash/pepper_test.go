@@ -0,0 +1,166 @@
+package hash
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newTestPeppers(t *testing.T, current string) *Peppers {
+	t.Helper()
+	p, err := NewPeppers(current, map[string][]byte{
+		"k1": []byte("first-secret"),
+		"k2": []byte("second-secret"),
+	})
+	if err != nil {
+		t.Fatalf("NewPeppers() failed: %v", err)
+	}
+	return p
+}
+
+func TestNewPeppers(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		keys    map[string][]byte
+		wantErr bool
+	}{
+		{name: "valid", current: "k1", keys: map[string][]byte{"k1": []byte("s")}, wantErr: false},
+		{name: "unknown current", current: "k2", keys: map[string][]byte{"k1": []byte("s")}, wantErr: true},
+		{name: "empty key", current: "k1", keys: map[string][]byte{"k1": nil}, wantErr: true},
+		{name: "id with dollar", current: "k$1", keys: map[string][]byte{"k$1": []byte("s")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPeppers(tt.current, tt.keys)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewPeppers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPeppersApply(t *testing.T) {
+	p := newTestPeppers(t, "k1")
+
+	a, err := p.Apply("k1", "password")
+	if err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+	b, err := p.Apply("k2", "password")
+	if err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+
+	if a == b {
+		t.Error("Apply() should differ between keys")
+	}
+
+	if _, err := p.Apply("k3", "password"); !errors.Is(err, ErrUnknownPepper) {
+		t.Errorf("Apply() error = %v, want ErrUnknownPepper", err)
+	}
+}
+
+func TestPepperedHash(t *testing.T) {
+	policy := Policy{Algorithm: AlgBcrypt, BcryptCost: 4, Peppers: newTestPeppers(t, "k1")}
+
+	hash, err := policy.Hash("password")
+	if err != nil {
+		t.Fatalf("Policy.Hash() failed: %v", err)
+	}
+
+	if !strings.HasPrefix(hash, "$pepper$k1$2a$04$") {
+		t.Errorf("Policy.Hash() = %s, want peppered bcrypt hash", hash)
+	}
+	if id, _ := PepperID(hash); id != "k1" {
+		t.Errorf("PepperID() = %q, want k1", id)
+	}
+	if !IsHash(hash) {
+		t.Error("IsHash() should return true for peppered hash")
+	}
+	if alg := Algorithm(hash); alg != AlgBcrypt {
+		t.Errorf("Algorithm() = %q, want %q", alg, AlgBcrypt)
+	}
+
+	if !policy.Check("password", hash) {
+		t.Error("Policy.Check() should return true for correct password")
+	}
+	if policy.Check("wrongpassword", hash) {
+		t.Error("Policy.Check() should return false for incorrect password")
+	}
+
+	// Without the pepper the hash cannot be verified.
+	if (Policy{Algorithm: AlgBcrypt}).Check("password", hash) {
+		t.Error("Policy.Check() should return false without peppers")
+	}
+}
+
+func TestNewWithDefaultPeppers(t *testing.T) {
+	withDefaultPolicy(t, Policy{Algorithm: AlgBcrypt, BcryptCost: 4, Peppers: newTestPeppers(t, "k1")})
+
+	hash, err := New("password", 4)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if !strings.HasPrefix(hash, "$pepper$k1$2a$04$") {
+		t.Errorf("New() = %s, want peppered bcrypt hash", hash)
+	}
+	if !Check("password", hash) {
+		t.Error("Check() should return true for correct password")
+	}
+
+	ok, newHash, err := CheckAndUpgrade("password", hash)
+	if err != nil || !ok || newHash != "" {
+		t.Errorf("CheckAndUpgrade() = (%v, %q, %v), want (true, \"\", nil)", ok, newHash, err)
+	}
+}
+
+func TestPepperRotation(t *testing.T) {
+	oldPolicy := Policy{Algorithm: AlgBcrypt, BcryptCost: 4, Peppers: newTestPeppers(t, "k1")}
+	withDefaultPolicy(t, Policy{Algorithm: AlgBcrypt, BcryptCost: 4, Peppers: newTestPeppers(t, "k2")})
+
+	oldHash, err := oldPolicy.Hash("password")
+	if err != nil {
+		t.Fatalf("Policy.Hash() failed: %v", err)
+	}
+
+	if !Check("password", oldHash) {
+		t.Error("Check() should verify hash under retired pepper")
+	}
+	if !NeedsRehash(oldHash, DefaultPolicy) {
+		t.Error("NeedsRehash() should return true for retired pepper")
+	}
+
+	ok, newHash, err := CheckAndUpgrade("password", oldHash)
+	if err != nil || !ok {
+		t.Fatalf("CheckAndUpgrade() = (%v, %q, %v), want ok", ok, newHash, err)
+	}
+	if id, _ := PepperID(newHash); id != "k2" {
+		t.Errorf("CheckAndUpgrade() newHash pepper = %q, want k2", id)
+	}
+	if NeedsRehash(newHash, DefaultPolicy) {
+		t.Error("NeedsRehash() should return false for current pepper")
+	}
+}
+
+func TestNeedsRehashPepperMismatch(t *testing.T) {
+	peppers := newTestPeppers(t, "k1")
+	plain, err := New("password", 4)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if !NeedsRehash(plain, Policy{Algorithm: AlgBcrypt, BcryptCost: 4, Peppers: peppers}) {
+		t.Error("NeedsRehash() should return true for hash without pepper")
+	}
+
+	peppered, err := Policy{Algorithm: AlgBcrypt, BcryptCost: 4, Peppers: peppers}.Hash("password")
+	if err != nil {
+		t.Fatalf("Policy.Hash() failed: %v", err)
+	}
+	if !NeedsRehash(peppered, Policy{Algorithm: AlgBcrypt, BcryptCost: 4}) {
+		t.Error("NeedsRehash() should return true for peppered hash when pepper is disabled")
+	}
+}
@@ -0,0 +1,141 @@
+package hash
+
+import "fmt"
+
+// Rehasher is implemented by hashers that can tell whether a hash of their own algorithm
+// was created with parameters other than their current configuration.
+type Rehasher interface {
+	// NeedsRehash reports whether the hash does not match the hasher's configuration.
+	NeedsRehash(hash string) bool
+}
+
+// Policy describes how new password hashes are created.
+type Policy struct {
+	// Algorithm is the name of the algorithm new hashes are created with, e.g. AlgArgon2id.
+	Algorithm string
+
+	// BcryptCost is the bcrypt cost parameter used when Algorithm is AlgBcrypt or AlgBcryptSHA512.
+	BcryptCost int
+
+	// Argon2 holds the parameters used when Algorithm is AlgArgon2id.
+	Argon2 Argon2Params
+
+	// Scrypt holds the parameters used when Algorithm is AlgScrypt.
+	Scrypt ScryptParams
+
+	// PBKDF2 holds the parameters used when Algorithm is AlgPBKDF2SHA256.
+	PBKDF2 PBKDF2Params
+
+	// Peppers, when set, mixes a server-side secret into passwords before hashing.
+	Peppers *Peppers
+}
+
+// DefaultPolicy is the policy used by Check and CheckAndUpgrade.
+// Services can replace it at startup, e.g. to switch new hashes to Argon2id.
+var DefaultPolicy = Policy{
+	Algorithm:  AlgBcrypt,
+	BcryptCost: DefaultRounds,
+	Argon2:     DefaultArgon2Params,
+	Scrypt:     DefaultScryptParams,
+	PBKDF2:     DefaultPBKDF2Params,
+}
+
+// Hasher returns the hasher configured by the policy.
+// Algorithms without parameters in Policy resolve to the registered hasher of that name.
+func (p Policy) Hasher() (Hasher, error) {
+	switch p.Algorithm {
+	case AlgBcrypt:
+		return BcryptHasher{Cost: p.BcryptCost}, nil
+	case AlgBcryptSHA512:
+		return BcryptSHA512Hasher{Cost: p.BcryptCost}, nil
+	case AlgArgon2id:
+		return Argon2idHasher{Params: p.Argon2}, nil
+	case AlgScrypt:
+		return ScryptHasher{Params: p.Scrypt}, nil
+	case AlgPBKDF2SHA256:
+		return PBKDF2Hasher{Params: p.PBKDF2}, nil
+	}
+
+	h, ok := Get(p.Algorithm)
+	if !ok {
+		return nil, fmt.Errorf("hash: unknown algorithm %q", p.Algorithm)
+	}
+	return h, nil
+}
+
+// Hash generates a hash from the password according to the policy.
+func (p Policy) Hash(password string) (string, error) {
+	h, err := p.Hasher()
+	if err != nil {
+		return "", err
+	}
+
+	if p.Peppers != nil {
+		return p.Peppers.hash(h, password)
+	}
+	return h.Hash(password)
+}
+
+// Check verifies if the password matches the hash, using the policy's peppers for peppered hashes.
+// Returns true if password is correct, false otherwise.
+func (p Policy) Check(plainText, hash string) bool {
+	if IsPeppered(hash) {
+		return p.Peppers != nil && p.Peppers.verify(plainText, hash)
+	}
+
+	h, ok := Lookup(hash)
+	if !ok {
+		return false
+	}
+	return h.Verify(plainText, hash)
+}
+
+// NeedsRehash reports whether the hash was created with another algorithm or other
+// parameters than the policy prescribes, e.g. after raising the bcrypt cost.
+// With peppers configured, hashes without pepper or under a retired key need a rehash too.
+func NeedsRehash(hash string, policy Policy) bool {
+	id, inner, peppered := splitPepper(hash)
+	if peppered != (policy.Peppers != nil) {
+		return true
+	}
+	if peppered {
+		if id != policy.Peppers.Current() {
+			return true
+		}
+		hash = inner
+	}
+
+	if Algorithm(hash) != policy.Algorithm {
+		return true
+	}
+
+	h, err := policy.Hasher()
+	if err != nil {
+		return true
+	}
+
+	if r, ok := h.(Rehasher); ok {
+		return r.NeedsRehash(hash)
+	}
+	return false
+}
+
+// CheckAndUpgrade verifies the password against the hash and, when the hash is stale
+// according to DefaultPolicy, creates a replacement hash.
+// newHash is empty if the password is wrong or the hash is up to date,
+// so callers only need to save it when it is non-empty.
+func CheckAndUpgrade(plainText, hash string) (ok bool, newHash string, err error) {
+	if !DefaultPolicy.Check(plainText, hash) {
+		return false, "", nil
+	}
+
+	if !NeedsRehash(hash, DefaultPolicy) {
+		return true, "", nil
+	}
+
+	newHash, err = DefaultPolicy.Hash(plainText)
+	if err != nil {
+		return true, "", err
+	}
+	return true, newHash, nil
+}
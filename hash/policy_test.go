@@ -0,0 +1,114 @@
+package hash
+
+import "testing"
+
+// withDefaultPolicy replaces DefaultPolicy for the duration of the test.
+func withDefaultPolicy(t *testing.T, p Policy) {
+	t.Helper()
+	old := DefaultPolicy
+	DefaultPolicy = p
+	t.Cleanup(func() { DefaultPolicy = old })
+}
+
+func TestNeedsRehash(t *testing.T) {
+	bcrypt10, err := New("password", 10)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	argonHash, err := NewArgon2("password", testArgon2Params)
+	if err != nil {
+		t.Fatalf("NewArgon2() failed: %v", err)
+	}
+
+	strongerArgon2 := testArgon2Params
+	strongerArgon2.Time = 2
+
+	tests := []struct {
+		name   string
+		hash   string
+		policy Policy
+		want   bool
+	}{
+		{
+			name:   "bcrypt same cost",
+			hash:   bcrypt10,
+			policy: Policy{Algorithm: AlgBcrypt, BcryptCost: 10},
+			want:   false,
+		},
+		{
+			name:   "bcrypt raised cost",
+			hash:   bcrypt10,
+			policy: Policy{Algorithm: AlgBcrypt, BcryptCost: 11},
+			want:   true,
+		},
+		{
+			name:   "bcrypt to argon2id",
+			hash:   bcrypt10,
+			policy: Policy{Algorithm: AlgArgon2id, Argon2: testArgon2Params},
+			want:   true,
+		},
+		{
+			name:   "argon2id same params",
+			hash:   argonHash,
+			policy: Policy{Algorithm: AlgArgon2id, Argon2: testArgon2Params},
+			want:   false,
+		},
+		{
+			name:   "argon2id stronger params",
+			hash:   argonHash,
+			policy: Policy{Algorithm: AlgArgon2id, Argon2: strongerArgon2},
+			want:   true,
+		},
+		{
+			name:   "unknown hash",
+			hash:   "plaintext",
+			policy: Policy{Algorithm: AlgBcrypt, BcryptCost: 10},
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NeedsRehash(tt.hash, tt.policy); got != tt.want {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyHashUnknownAlgorithm(t *testing.T) {
+	_, err := Policy{Algorithm: "unknown"}.Hash("password")
+	if err == nil {
+		t.Error("Policy.Hash() should return error for unknown algorithm")
+	}
+}
+
+func TestCheckAndUpgrade(t *testing.T) {
+	withDefaultPolicy(t, Policy{Algorithm: AlgArgon2id, Argon2: testArgon2Params})
+
+	oldHash, err := New("password", 4)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	ok, newHash, err := CheckAndUpgrade("wrongpassword", oldHash)
+	if err != nil || ok || newHash != "" {
+		t.Errorf("CheckAndUpgrade() = (%v, %q, %v), want (false, \"\", nil)", ok, newHash, err)
+	}
+
+	ok, newHash, err = CheckAndUpgrade("password", oldHash)
+	if err != nil || !ok {
+		t.Fatalf("CheckAndUpgrade() = (%v, %q, %v), want ok", ok, newHash, err)
+	}
+	if Algorithm(newHash) != AlgArgon2id {
+		t.Errorf("CheckAndUpgrade() newHash = %q, want argon2id hash", newHash)
+	}
+	if !Check("password", newHash) {
+		t.Error("upgraded hash should verify")
+	}
+
+	ok, again, err := CheckAndUpgrade("password", newHash)
+	if err != nil || !ok || again != "" {
+		t.Errorf("CheckAndUpgrade() = (%v, %q, %v), want (true, \"\", nil) for current hash", ok, again, err)
+	}
+}
@@ -0,0 +1,101 @@
+package hash
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrBusy is returned by Pool when all workers are busy and the queue is full.
+// HTTP handlers can map it to a 503 Service Unavailable response.
+var ErrBusy = errors.New("hash: pool busy")
+
+// Pool bounds the number of concurrent hashing operations, so bursts of logins
+// cannot starve the server of CPU.
+// At most workers operations run at once and at most queue more wait for a worker;
+// further operations fail immediately with ErrBusy.
+type Pool struct {
+	workers chan struct{}
+	slots   chan struct{}
+}
+
+// NewPool creates a pool running at most workers operations with queue waiting operations.
+// workers below 1 is treated as 1 and a negative queue as 0.
+func NewPool(workers int, queue int) *Pool {
+	workers = max(workers, 1)
+	queue = max(queue, 0)
+
+	return &Pool{
+		workers: make(chan struct{}, workers),
+		slots:   make(chan struct{}, workers+queue),
+	}
+}
+
+// Do runs f on a worker of the pool.
+// It returns ErrBusy if the queue is full, or the context error if ctx is done
+// before f has finished. f keeps running in the background after ctx is done,
+// as hashing cannot be interrupted, and occupies its worker until it returns.
+func (p *Pool) Do(ctx context.Context, f func()) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case p.slots <- struct{}{}:
+	default:
+		return ErrBusy
+	}
+
+	select {
+	case p.workers <- struct{}{}:
+	case <-ctx.Done():
+		<-p.slots
+		return ctx.Err()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer func() {
+			<-p.workers
+			<-p.slots
+			close(done)
+		}()
+		f()
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// New generates a bcrypt hash like New, running on a worker of the pool.
+func (p *Pool) New(ctx context.Context, password string, rounds ...int) (string, error) {
+	var hash string
+	var err error
+	if perr := p.Do(ctx, func() { hash, err = New(password, rounds...) }); perr != nil {
+		return "", perr
+	}
+	return hash, err
+}
+
+// Check verifies the password like Check, running on a worker of the pool.
+func (p *Pool) Check(ctx context.Context, plainText, hash string) (bool, error) {
+	var ok bool
+	if err := p.Do(ctx, func() { ok = Check(plainText, hash) }); err != nil {
+		return false, err
+	}
+	return ok, nil
+}
+
+// CheckAndUpgrade verifies and upgrades the hash like CheckAndUpgrade, running on a worker of the pool.
+func (p *Pool) CheckAndUpgrade(ctx context.Context, plainText, hash string) (bool, string, error) {
+	var ok bool
+	var newHash string
+	var err error
+	if perr := p.Do(ctx, func() { ok, newHash, err = CheckAndUpgrade(plainText, hash) }); perr != nil {
+		return false, "", perr
+	}
+	return ok, newHash, err
+}
@@ -0,0 +1,111 @@
+package hash
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPoolNewAndCheck(t *testing.T) {
+	p := NewPool(2, 2)
+	ctx := context.Background()
+
+	hash, err := p.New(ctx, "password", 4)
+	if err != nil {
+		t.Fatalf("Pool.New() failed: %v", err)
+	}
+
+	ok, err := p.Check(ctx, "password", hash)
+	if err != nil || !ok {
+		t.Errorf("Pool.Check() = (%v, %v), want (true, nil)", ok, err)
+	}
+
+	ok, err = p.Check(ctx, "wrongpassword", hash)
+	if err != nil || ok {
+		t.Errorf("Pool.Check() = (%v, %v), want (false, nil)", ok, err)
+	}
+}
+
+func TestPoolNewError(t *testing.T) {
+	p := NewPool(1, 0)
+
+	if _, err := p.New(context.Background(), "password", 99); err == nil {
+		t.Error("Pool.New() should return hashing error")
+	}
+}
+
+func TestPoolBusy(t *testing.T) {
+	p := NewPool(1, 1)
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	// Occupy the only worker.
+	go func() {
+		_ = p.Do(context.Background(), func() {
+			close(started)
+			<-release
+		})
+	}()
+	<-started
+
+	// Occupy the only queue slot.
+	queued := make(chan error, 1)
+	go func() {
+		queued <- p.Do(context.Background(), func() {})
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for len(p.slots) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := p.Do(context.Background(), func() {}); !errors.Is(err, ErrBusy) {
+		t.Errorf("Pool.Do() error = %v, want ErrBusy", err)
+	}
+
+	close(release)
+	if err := <-queued; err != nil {
+		t.Errorf("queued Pool.Do() error = %v, want nil", err)
+	}
+}
+
+func TestPoolContextDeadline(t *testing.T) {
+	p := NewPool(1, 1)
+	release := make(chan struct{})
+	defer close(release)
+
+	started := make(chan struct{})
+	go func() {
+		_ = p.Do(context.Background(), func() {
+			close(started)
+			<-release
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := p.Check(ctx, "password", "$2a$04$"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Pool.Check() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	if len(p.slots) != 1 {
+		t.Errorf("queue slots in use = %d, want 1 after cancellation", len(p.slots))
+	}
+}
+
+func TestPoolCanceledContext(t *testing.T) {
+	p := NewPool(1, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	if err := p.Do(ctx, func() { called = true }); !errors.Is(err, context.Canceled) {
+		t.Errorf("Pool.Do() error = %v, want context.Canceled", err)
+	}
+	if called {
+		t.Error("Pool.Do() should not run f with canceled context")
+	}
+}
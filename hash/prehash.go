@@ -0,0 +1,93 @@
+package hash
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptSHA512Prefix marks bcrypt hashes of pre-hashed passwords.
+// The marker replaces the leading "$" of the bcrypt hash, e.g. $bcrypt-sha512$2a$12$...
+const bcryptSHA512Prefix = "$bcrypt-sha512$"
+
+// prehashLength is the number of bytes of the encoded digest passed to bcrypt,
+// which only ever reads the first 72 bytes of its input.
+const prehashLength = 72
+
+// NewBcryptSHA512 generates a bcrypt hash of the SHA-512 pre-hashed input string with optional custom rounds.
+// Unlike New, it accepts passwords of any length.
+// If no rounds are specified, DefaultRounds is used.
+func NewBcryptSHA512(password string, rounds ...int) (string, error) {
+	inner, err := bcryptHash(prehash(password), bcryptCost(rounds))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(bcryptSHA512Prefix, "$") + inner, nil
+}
+
+// CheckBcryptSHA512 verifies if the password matches the pre-hashed bcrypt hash.
+// Returns true if password is correct, false otherwise.
+func CheckBcryptSHA512(plainText, hash string) bool {
+	inner, ok := unwrapBcryptSHA512(hash)
+	if !ok {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(inner), []byte(prehash(plainText))) == nil
+}
+
+// prehash returns the base64 encoded SHA-512 digest of the password, cut to what bcrypt reads.
+// This keeps 432 bits of the digest and removes NUL bytes and the 72-byte limit.
+func prehash(password string) string {
+	sum := sha512.Sum512([]byte(password))
+	return base64.StdEncoding.EncodeToString(sum[:])[:prehashLength]
+}
+
+// unwrapBcryptSHA512 returns the plain bcrypt hash inside a pre-hashed bcrypt hash.
+func unwrapBcryptSHA512(hash string) (string, bool) {
+	if !strings.HasPrefix(hash, bcryptSHA512Prefix) {
+		return "", false
+	}
+	return hash[len(bcryptSHA512Prefix)-1:], true
+}
+
+// BcryptSHA512Hasher is the bcrypt Hasher with SHA-512 pre-hashing,
+// for passwords longer than bcrypt's 72-byte limit.
+type BcryptSHA512Hasher struct {
+	// Cost is the bcrypt cost parameter. Zero means DefaultRounds.
+	Cost int
+}
+
+// Name returns "bcrypt-sha512".
+func (BcryptSHA512Hasher) Name() string {
+	return AlgBcryptSHA512
+}
+
+// Hash generates a pre-hashed bcrypt hash from the password.
+func (h BcryptSHA512Hasher) Hash(password string) (string, error) {
+	if h.Cost == 0 {
+		return NewBcryptSHA512(password)
+	}
+	return NewBcryptSHA512(password, h.Cost)
+}
+
+// Verify reports whether the password matches the pre-hashed bcrypt hash.
+func (BcryptSHA512Hasher) Verify(password, hash string) bool {
+	return CheckBcryptSHA512(password, hash)
+}
+
+// IsHash reports whether the input is a valid pre-hashed bcrypt hash.
+func (BcryptSHA512Hasher) IsHash(input string) bool {
+	inner, ok := unwrapBcryptSHA512(input)
+	return ok && BcryptHasher{}.IsHash(inner)
+}
+
+// NeedsRehash reports whether the pre-hashed bcrypt hash was created with a different cost.
+func (h BcryptSHA512Hasher) NeedsRehash(hash string) bool {
+	inner, ok := unwrapBcryptSHA512(hash)
+	if !ok {
+		return true
+	}
+	return BcryptHasher(h).NeedsRehash(inner)
+}
@@ -0,0 +1,85 @@
+package hash
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewBcryptSHA512LongPassword(t *testing.T) {
+	longPassword := strings.Repeat("a", 200)
+
+	hash, err := NewBcryptSHA512(longPassword, 4)
+	if err != nil {
+		t.Fatalf("NewBcryptSHA512() failed: %v", err)
+	}
+
+	if !strings.HasPrefix(hash, "$bcrypt-sha512$2a$04$") {
+		t.Errorf("NewBcryptSHA512() = %s, want bcrypt-sha512 marker", hash)
+	}
+
+	if !Check(longPassword, hash) {
+		t.Error("Check() should return true for correct long password")
+	}
+
+	// Passwords sharing the first 72 bytes must not collide.
+	if Check(strings.Repeat("a", 199)+"b", hash) {
+		t.Error("Check() should return false for password differing after 72 bytes")
+	}
+}
+
+func TestBcryptSHA512DoesNotVerifyPlainBcrypt(t *testing.T) {
+	password := "testpassword123"
+	plain, err := New(password, 4)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if CheckBcryptSHA512(password, plain) {
+		t.Error("CheckBcryptSHA512() should return false for plain bcrypt hash")
+	}
+	if !Check(password, plain) {
+		t.Error("Check() should still verify plain bcrypt hash")
+	}
+}
+
+func TestBcryptSHA512Hasher(t *testing.T) {
+	h := BcryptSHA512Hasher{Cost: 4}
+
+	hash, err := h.Hash("password")
+	if err != nil {
+		t.Fatalf("Hash() failed: %v", err)
+	}
+
+	if Algorithm(hash) != AlgBcryptSHA512 {
+		t.Errorf("Algorithm() = %q, want %q", Algorithm(hash), AlgBcryptSHA512)
+	}
+	if !IsHash(hash) {
+		t.Error("IsHash() should return true for pre-hashed bcrypt hash")
+	}
+	if IsHash("$bcrypt-sha512$2a$99$" + strings.Repeat("a", 53)) {
+		t.Error("IsHash() should return false for invalid inner bcrypt hash")
+	}
+	if h.NeedsRehash(hash) {
+		t.Error("NeedsRehash() should return false for same cost")
+	}
+	if !(BcryptSHA512Hasher{Cost: 5}).NeedsRehash(hash) {
+		t.Error("NeedsRehash() should return true for different cost")
+	}
+}
+
+func TestCheckAndUpgradeToBcryptSHA512(t *testing.T) {
+	withDefaultPolicy(t, Policy{Algorithm: AlgBcryptSHA512, BcryptCost: 4})
+
+	plain, err := New("password", 4)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	ok, newHash, err := CheckAndUpgrade("password", plain)
+	if err != nil || !ok {
+		t.Fatalf("CheckAndUpgrade() = (%v, %q, %v), want ok", ok, newHash, err)
+	}
+	if Algorithm(newHash) != AlgBcryptSHA512 {
+		t.Errorf("CheckAndUpgrade() newHash = %q, want bcrypt-sha512 hash", newHash)
+	}
+}
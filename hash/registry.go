@@ -0,0 +1,122 @@
+package hash
+
+import (
+	"strings"
+	"sync"
+)
+
+// Algorithm names of the built-in hashers.
+const (
+	AlgBcrypt       = "bcrypt"
+	AlgArgon2id     = "argon2id"
+	AlgScrypt       = "scrypt"
+	AlgPBKDF2SHA256 = "pbkdf2-sha256"
+	AlgBcryptSHA512 = "bcrypt-sha512"
+)
+
+// Hasher is a password hashing algorithm that produces self-describing encoded hashes.
+type Hasher interface {
+	// Name returns the algorithm name, e.g. "bcrypt" or "argon2id".
+	Name() string
+
+	// Hash generates an encoded hash from the password.
+	Hash(password string) (string, error)
+
+	// Verify reports whether the password matches the encoded hash.
+	Verify(password, hash string) bool
+
+	// IsHash reports whether the input is a well-formed hash of this algorithm.
+	IsHash(input string) bool
+}
+
+type registration struct {
+	prefix string
+	hasher Hasher
+}
+
+var registry = struct {
+	sync.RWMutex
+	byName   map[string]Hasher
+	prefixes []registration
+}{
+	byName: map[string]Hasher{},
+}
+
+func init() {
+	Register(BcryptHasher{}, "$2a$", "$2b$", "$2y$")
+	Register(Argon2idHasher{}, argon2idPrefix)
+	Register(ScryptHasher{}, scryptPrefix)
+	Register(PBKDF2Hasher{}, pbkdf2SHA256Prefix)
+	Register(BcryptSHA512Hasher{}, bcryptSHA512Prefix)
+	for alg := range legacyDigests {
+		Register(LegacyHasher{Algorithm: alg}, legacyPrefix(alg))
+	}
+}
+
+// Register makes a hasher available for verification of hashes starting with any of the prefixes.
+// Registering a name or prefix again replaces the previous hasher.
+// It panics if h is nil or no prefix is given.
+func Register(h Hasher, prefixes ...string) {
+	if h == nil {
+		panic("hash: Register hasher is nil")
+	}
+	if len(prefixes) == 0 {
+		panic("hash: Register called without prefixes for " + h.Name())
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	registry.byName[h.Name()] = h
+	for _, prefix := range prefixes {
+		replaced := false
+		for i := range registry.prefixes {
+			if registry.prefixes[i].prefix == prefix {
+				registry.prefixes[i].hasher = h
+				replaced = true
+			}
+		}
+		if !replaced {
+			registry.prefixes = append(registry.prefixes, registration{prefix: prefix, hasher: h})
+		}
+	}
+}
+
+// Lookup returns the hasher registered for the prefix of the hash.
+// When several prefixes match, the longest one wins.
+func Lookup(hash string) (Hasher, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	var found registration
+	for _, r := range registry.prefixes {
+		if strings.HasPrefix(hash, r.prefix) && len(r.prefix) > len(found.prefix) {
+			found = r
+		}
+	}
+
+	return found.hasher, found.hasher != nil
+}
+
+// Get returns the hasher registered under the algorithm name.
+func Get(name string) (Hasher, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	h, ok := registry.byName[name]
+	return h, ok
+}
+
+// Algorithm returns the name of the algorithm the hash was created with,
+// or an empty string if it is not recognised. Peppered hashes report their inner algorithm.
+func Algorithm(hash string) string {
+	if _, inner, ok := splitPepper(hash); ok {
+		hash = inner
+	}
+
+	h, ok := Lookup(hash)
+	if !ok {
+		return ""
+	}
+	return h.Name()
+}
@@ -0,0 +1,130 @@
+package hash
+
+import (
+	"strings"
+	"testing"
+)
+
+// reverseHasher is a toy third-party hasher used to exercise Register.
+type reverseHasher struct{}
+
+func (reverseHasher) Name() string { return "reverse" }
+
+func (reverseHasher) Hash(password string) (string, error) {
+	runes := []rune(password)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return "$reverse$" + string(runes), nil
+}
+
+func (h reverseHasher) Verify(password, hash string) bool {
+	want, _ := h.Hash(password)
+	return want == hash
+}
+
+func (reverseHasher) IsHash(input string) bool {
+	return strings.HasPrefix(input, "$reverse$")
+}
+
+func TestLookup(t *testing.T) {
+	argonHash, err := NewArgon2("password", testArgon2Params)
+	if err != nil {
+		t.Fatalf("NewArgon2() failed: %v", err)
+	}
+	scryptHash, err := NewScrypt("password", testScryptParams)
+	if err != nil {
+		t.Fatalf("NewScrypt() failed: %v", err)
+	}
+	pbkdf2Hash, err := NewPBKDF2("password", testPBKDF2Params)
+	if err != nil {
+		t.Fatalf("NewPBKDF2() failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		hash string
+		want string
+	}{
+		{name: "bcrypt 2a", hash: Make("password"), want: AlgBcrypt},
+		{name: "bcrypt 2b", hash: "$2b$10$" + strings.Repeat("a", 53), want: AlgBcrypt},
+		{name: "bcrypt 2y", hash: "$2y$10$" + strings.Repeat("a", 53), want: AlgBcrypt},
+		{name: "argon2id", hash: argonHash, want: AlgArgon2id},
+		{name: "scrypt", hash: scryptHash, want: AlgScrypt},
+		{name: "pbkdf2-sha256", hash: pbkdf2Hash, want: AlgPBKDF2SHA256},
+		{name: "unknown", hash: "$md5$abc", want: ""},
+		{name: "empty", hash: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Algorithm(tt.hash); got != tt.want {
+				t.Errorf("Algorithm() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckAllAlgorithms(t *testing.T) {
+	password := "testpassword123"
+
+	hashers := []Hasher{
+		BcryptHasher{Cost: 4},
+		Argon2idHasher{Params: testArgon2Params},
+		ScryptHasher{Params: testScryptParams},
+		PBKDF2Hasher{Params: testPBKDF2Params},
+	}
+
+	for _, h := range hashers {
+		t.Run(h.Name(), func(t *testing.T) {
+			hash, err := h.Hash(password)
+			if err != nil {
+				t.Fatalf("Hash() failed: %v", err)
+			}
+
+			if !Check(password, hash) {
+				t.Error("Check() should return true for correct password")
+			}
+			if Check("wrongpassword", hash) {
+				t.Error("Check() should return false for incorrect password")
+			}
+			if !IsHash(hash) {
+				t.Error("IsHash() should return true for generated hash")
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register(reverseHasher{}, "$reverse$")
+
+	h, ok := Get("reverse")
+	if !ok {
+		t.Fatal("Get() should find registered hasher")
+	}
+
+	hash, err := h.Hash("abc")
+	if err != nil {
+		t.Fatalf("Hash() failed: %v", err)
+	}
+
+	if !Check("abc", hash) {
+		t.Error("Check() should verify hash of registered hasher")
+	}
+	if Check("abd", hash) {
+		t.Error("Check() should return false for incorrect password")
+	}
+	if !IsHash(hash) {
+		t.Error("IsHash() should return true for hash of registered hasher")
+	}
+}
+
+func TestRegisterPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Register() should panic without prefixes")
+		}
+	}()
+
+	Register(reverseHasher{})
+}
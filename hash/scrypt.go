@@ -0,0 +1,175 @@
+package hash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// scryptPrefix is the identifier prefix of scrypt hashes.
+const scryptPrefix = "$scrypt$"
+
+// ErrInvalidScryptHash is returned when a string is not a valid scrypt hash.
+var ErrInvalidScryptHash = errors.New("hash: invalid scrypt hash")
+
+// ScryptParams holds the tunable parameters of scrypt.
+type ScryptParams struct {
+	// LogN is the base-2 logarithm of the CPU/memory cost N.
+	LogN uint8
+
+	// R is the block size.
+	R int
+
+	// P is the parallelization factor.
+	P int
+
+	// SaltLength is the length of the random salt in bytes.
+	SaltLength int
+
+	// KeyLength is the length of the derived key in bytes.
+	KeyLength int
+}
+
+// DefaultScryptParams are the default scrypt parameters (N=2^16, r=8, p=1).
+var DefaultScryptParams = ScryptParams{
+	LogN:       16,
+	R:          8,
+	P:          1,
+	SaltLength: 16,
+	KeyLength:  32,
+}
+
+// NewScrypt generates a scrypt hash from the input string.
+// If no params are specified, DefaultScryptParams is used.
+// The result uses the passlib format $scrypt$ln=16,r=8,p=1$<salt>$<hash>.
+func NewScrypt(password string, params ...ScryptParams) (string, error) {
+	p := DefaultScryptParams
+	if len(params) > 0 {
+		p = params[0]
+	}
+	if p.SaltLength <= 0 || p.KeyLength <= 0 {
+		return "", errors.New("hash: scrypt salt and key length must be greater than 0")
+	}
+
+	salt := make([]byte, p.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, 1<<p.LogN, p.R, p.P, p.KeyLength)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%sln=%d,r=%d,p=%d$%s$%s",
+		scryptPrefix, p.LogN, p.R, p.P,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// CheckScrypt verifies if the password matches the scrypt hash.
+// Returns true if password is correct, false otherwise.
+func CheckScrypt(plainText, hash string) bool {
+	p, salt, key, err := decodeScrypt(hash)
+	if err != nil {
+		return false
+	}
+
+	other, err := scrypt.Key([]byte(plainText), salt, 1<<p.LogN, p.R, p.P, p.KeyLength)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
+
+// IsScryptHash checks if the input string is a valid scrypt hash.
+func IsScryptHash(input string) bool {
+	_, _, _, err := decodeScrypt(input)
+	return err == nil
+}
+
+// decodeScrypt parses a scrypt hash into its parameters, salt and key.
+func decodeScrypt(hash string) (ScryptParams, []byte, []byte, error) {
+	var p ScryptParams
+
+	if !strings.HasPrefix(hash, scryptPrefix) {
+		return p, nil, nil, ErrInvalidScryptHash
+	}
+
+	// "", "scrypt", "ln=..,r=..,p=..", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 5 {
+		return p, nil, nil, ErrInvalidScryptHash
+	}
+
+	if _, err := fmt.Sscanf(parts[2], "ln=%d,r=%d,p=%d", &p.LogN, &p.R, &p.P); err != nil {
+		return p, nil, nil, ErrInvalidScryptHash
+	}
+	if p.LogN == 0 || p.LogN > 63 || p.R <= 0 || p.P <= 0 {
+		return p, nil, nil, ErrInvalidScryptHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(salt) == 0 {
+		return p, nil, nil, ErrInvalidScryptHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, ErrInvalidScryptHash
+	}
+
+	p.SaltLength = len(salt)
+	p.KeyLength = len(key)
+
+	return p, salt, key, nil
+}
+
+// ScryptHasher is the scrypt Hasher.
+type ScryptHasher struct {
+	// Params are the scrypt parameters. The zero value means DefaultScryptParams.
+	Params ScryptParams
+}
+
+// Name returns "scrypt".
+func (ScryptHasher) Name() string {
+	return AlgScrypt
+}
+
+// Hash generates a scrypt hash from the password.
+func (h ScryptHasher) Hash(password string) (string, error) {
+	if h.Params == (ScryptParams{}) {
+		return NewScrypt(password)
+	}
+	return NewScrypt(password, h.Params)
+}
+
+// Verify reports whether the password matches the scrypt hash.
+func (ScryptHasher) Verify(password, hash string) bool {
+	return CheckScrypt(password, hash)
+}
+
+// IsHash reports whether the input is a valid scrypt hash.
+func (ScryptHasher) IsHash(input string) bool {
+	return IsScryptHash(input)
+}
+
+// NeedsRehash reports whether the scrypt hash was created with different parameters.
+func (h ScryptHasher) NeedsRehash(hash string) bool {
+	p, _, _, err := decodeScrypt(hash)
+	if err != nil {
+		return true
+	}
+
+	want := h.Params
+	if want == (ScryptParams{}) {
+		want = DefaultScryptParams
+	}
+	return p != want
+}
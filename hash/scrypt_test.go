@@ -0,0 +1,72 @@
+package hash
+
+import (
+	"strings"
+	"testing"
+)
+
+// testScryptParams keeps the tests fast while exercising the full code path.
+var testScryptParams = ScryptParams{
+	LogN:       10,
+	R:          8,
+	P:          1,
+	SaltLength: 16,
+	KeyLength:  32,
+}
+
+func TestNewScrypt(t *testing.T) {
+	password := "testpassword123"
+
+	hash, err := NewScrypt(password, testScryptParams)
+	if err != nil {
+		t.Fatalf("NewScrypt() failed: %v", err)
+	}
+
+	if !strings.HasPrefix(hash, "$scrypt$ln=10,r=8,p=1$") {
+		t.Errorf("NewScrypt() = %s, want scrypt prefix", hash)
+	}
+
+	if !CheckScrypt(password, hash) {
+		t.Error("CheckScrypt() should return true for correct password")
+	}
+
+	if CheckScrypt("wrongpassword", hash) {
+		t.Error("CheckScrypt() should return false for incorrect password")
+	}
+}
+
+func TestCheckScryptPasslibVector(t *testing.T) {
+	// Generated by passlib.hash.scrypt.
+	hash := "$scrypt$ln=16,r=8,p=1$aM15713r3Xsvxbi31lqr1Q$nFNh2CVHVjNldFVKDHDlm4CbdRSCdEBsjjJxD+iCs5E"
+
+	if !CheckScrypt("password", hash) {
+		t.Error("CheckScrypt() should verify passlib hash")
+	}
+}
+
+func TestIsScryptHash(t *testing.T) {
+	hash, err := NewScrypt("password", testScryptParams)
+	if err != nil {
+		t.Fatalf("NewScrypt() failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "valid hash", input: hash, want: true},
+		{name: "empty string", input: "", want: false},
+		{name: "zero cost", input: strings.Replace(hash, "ln=10", "ln=0", 1), want: false},
+		{name: "missing parts", input: "$scrypt$ln=10,r=8,p=1$c2FsdA", want: false},
+		{name: "invalid params", input: "$scrypt$n=10$c2FsdA$c2FsdA", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsScryptHash(tt.input); got != tt.want {
+				t.Errorf("IsScryptHash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package httputil
+
+import (
+	"errors"
+
+	"github.com/ducconit/gobase/apperror"
+	"github.com/gin-gonic/gin"
+)
+
+// InternalErrorMessage is the message sent by Fail for errors that are not an AppError.
+var InternalErrorMessage = "Internal server error"
+
+// TranslateMessage, when set, is used by Fail to translate the message key of an AppError,
+// e.g. according to the request's Accept-Language. It returns the fallback if it has no translation.
+var TranslateMessage func(c *gin.Context, key string, fallback string) string
+
+// Fail sends the error response of err.
+// If err wraps an *apperror.AppError, its status, code, message and details are sent;
+// errors with code ErrValidation are sent with ValidationError. Any other error is sent as
+// InternalServerError with InternalErrorMessage, so internals never leak; so is an AppError
+// without a 4xx or 5xx status, e.g. a literal missing its Status.
+// err is also recorded with c.Error for logging. A nil err is sent as InternalServerError
+// without being recorded.
+func Fail(c *gin.Context, err error) {
+	if err == nil {
+		InternalServerError(c, InternalErrorMessage)
+		return
+	}
+	_ = c.Error(err)
+
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) || appErr.Status < 400 || appErr.Status > 599 {
+		InternalServerError(c, InternalErrorMessage)
+		return
+	}
+
+	message := appErr.Message
+	if TranslateMessage != nil && appErr.MessageKey != "" {
+		message = TranslateMessage(c, appErr.MessageKey, message)
+	}
+
+	switch {
+	case appErr.Code == ErrValidation:
+		ValidationError(c, appErr.Details, message)
+	case len(appErr.Details) > 0:
+		Error(c, appErr.Status, appErr.Code, message, appErr.Details)
+	default:
+		Error[any](c, appErr.Status, appErr.Code, message)
+	}
+}
@@ -0,0 +1,128 @@
+package httputil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ducconit/gobase/apperror"
+	"github.com/gin-gonic/gin"
+)
+
+var errTestConflict = apperror.NewCatalog().Define("USER_EXISTS", http.StatusConflict, "user.exists", "User already exists")
+
+func TestFail(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantMessage string
+		wantExtra   map[string]any
+	}{
+		{
+			name:        "catalog error",
+			err:         apperror.ErrNotFound,
+			wantStatus:  http.StatusNotFound,
+			wantCode:    ErrNotFound,
+			wantMessage: "Not found",
+		},
+		{
+			name:        "wrapped domain error with details",
+			err:         fmt.Errorf("signup: %w", errTestConflict.WithCause(errors.New("duplicate key")).WithDetails(map[string]any{"field": "email"})),
+			wantStatus:  http.StatusConflict,
+			wantCode:    "USER_EXISTS",
+			wantMessage: "User already exists",
+			wantExtra:   map[string]any{"field": "email"},
+		},
+		{
+			name:        "validation error",
+			err:         apperror.ErrValidation.WithDetails(map[string]any{"email": "is required"}),
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantCode:    ErrValidation,
+			wantMessage: "Validation failed",
+			wantExtra:   map[string]any{"email": "is required"},
+		},
+		{
+			name:        "unknown error",
+			err:         errors.New("pq: connection refused to 10.0.0.5"),
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    ErrInternalServer,
+			wantMessage: InternalErrorMessage,
+		},
+		{
+			name:        "app error without status",
+			err:         &apperror.AppError{Code: "X", Message: "m"},
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    ErrInternalServer,
+			wantMessage: InternalErrorMessage,
+		},
+		{
+			name:        "nil error",
+			err:         nil,
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    ErrInternalServer,
+			wantMessage: InternalErrorMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/", nil)
+
+			Fail(c, tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			var resp JsonResponse[any, map[string]any]
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if resp.Code != tt.wantCode || resp.Message != tt.wantMessage {
+				t.Errorf("response = (%q, %q), want (%q, %q)", resp.Code, resp.Message, tt.wantCode, tt.wantMessage)
+			}
+			if fmt.Sprint(resp.Extra) != fmt.Sprint(tt.wantExtra) {
+				t.Errorf("extra = %v, want %v", resp.Extra, tt.wantExtra)
+			}
+			if tt.err == nil {
+				if len(c.Errors) != 0 {
+					t.Errorf("c.Errors = %v, want none recorded", c.Errors)
+				}
+			} else if len(c.Errors) != 1 || !errors.Is(c.Errors[0].Err, tt.err) {
+				t.Errorf("c.Errors = %v, want the original error recorded", c.Errors)
+			}
+		})
+	}
+}
+
+func TestFailTranslate(t *testing.T) {
+	TranslateMessage = func(c *gin.Context, key, fallback string) string {
+		if key == "error.not_found" && c.GetHeader("Accept-Language") == "vi" {
+			return "Không tìm thấy"
+		}
+		return fallback
+	}
+	t.Cleanup(func() { TranslateMessage = nil })
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/", nil)
+	c.Request.Header.Set("Accept-Language", "vi")
+
+	Fail(c, apperror.ErrNotFound)
+
+	var resp JsonResponse[any, any]
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Message != "Không tìm thấy" {
+		t.Errorf("message = %q, want translated message", resp.Message)
+	}
+}
@@ -0,0 +1,101 @@
+package httputil
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType is the media type of RFC 9457 Problem Details.
+const ProblemContentType = "application/problem+json"
+
+// ErrorFormat selects how error responses are written.
+type ErrorFormat int
+
+const (
+	// FormatEnvelope writes errors in the JsonResponse envelope.
+	FormatEnvelope ErrorFormat = iota
+
+	// FormatProblem writes errors as RFC 9457 Problem Details.
+	FormatProblem
+
+	// FormatNegotiate writes Problem Details to clients whose Accept header lists
+	// application/problem+json, and the JsonResponse envelope to all others.
+	FormatNegotiate
+)
+
+// ErrorResponseFormat is the format used by Error and the helpers built on it.
+var ErrorResponseFormat = FormatEnvelope
+
+// ProblemTypeBaseURI, when set, is joined with the error code to form the problem "type",
+// e.g. "https://errors.example.com/" gives "https://errors.example.com/404".
+// When empty, "about:blank" is used and the title is the HTTP status text.
+var ProblemTypeBaseURI = ""
+
+// Problem is an RFC 9457 Problem Details object with this package's extension members.
+type Problem struct {
+	// Type is a URI reference identifying the problem type.
+	Type string `json:"type"`
+
+	// Title is a short summary of the problem type.
+	Title string `json:"title"`
+
+	// Status is the HTTP status code.
+	Status int `json:"status"`
+
+	// Detail is the human-readable explanation of this occurrence, i.e. the error message.
+	Detail string `json:"detail,omitempty"`
+
+	// Instance is the request path the problem occurred on.
+	Instance string `json:"instance,omitempty"`
+
+	// Code is the application error code, as in JsonResponse.
+	Code string `json:"code"`
+
+	// RequestID is the request ID, as in JsonResponse.
+	RequestID string `json:"request_id,omitempty"`
+
+	// Errors holds validation errors.
+	Errors any `json:"errors,omitempty"`
+
+	// Extra holds additional information passed to Error.
+	Extra any `json:"extra,omitempty"`
+}
+
+// NewProblem builds the Problem Details of an error response.
+func NewProblem(c *gin.Context, httpStatusCode int, errorCode string, errorMessage string) Problem {
+	p := Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(httpStatusCode),
+		Status:    httpStatusCode,
+		Detail:    errorMessage,
+		Code:      errorCode,
+		RequestID: c.Writer.Header().Get(RequestIDHeaderKey),
+	}
+	if ProblemTypeBaseURI != "" {
+		p.Type = ProblemTypeBaseURI + errorCode
+	}
+	if c.Request != nil && c.Request.URL != nil {
+		p.Instance = c.Request.URL.Path
+	}
+	return p
+}
+
+// WantsProblem reports whether the error response of the request is written as Problem Details,
+// according to ErrorResponseFormat.
+func WantsProblem(c *gin.Context) bool {
+	switch ErrorResponseFormat {
+	case FormatProblem:
+		return true
+	case FormatNegotiate:
+		return c.Request != nil && strings.Contains(c.GetHeader("Accept"), ProblemContentType)
+	}
+	return false
+}
+
+// WriteProblem writes the Problem Details with the application/problem+json content type.
+func WriteProblem(c *gin.Context, p Problem) {
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(p.Status, p)
+}
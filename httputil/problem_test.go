@@ -0,0 +1,164 @@
+package httputil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// withErrorFormat sets ErrorResponseFormat for the duration of a test.
+func withErrorFormat(t *testing.T, format ErrorFormat) {
+	t.Helper()
+	old := ErrorResponseFormat
+	ErrorResponseFormat = format
+	t.Cleanup(func() { ErrorResponseFormat = old })
+}
+
+func TestProblemResponses(t *testing.T) {
+	withErrorFormat(t, FormatProblem)
+
+	tests := []struct {
+		name       string
+		respond    func(c *gin.Context)
+		wantStatus int
+		wantCode   string
+		wantDetail string
+		wantErrors bool
+		wantExtra  bool
+		wantTitle  string
+	}{
+		{
+			name:       "not found",
+			respond:    func(c *gin.Context) { NotFound(c, "user not found") },
+			wantStatus: http.StatusNotFound,
+			wantCode:   ErrNotFound,
+			wantDetail: "user not found",
+			wantTitle:  "Not Found",
+		},
+		{
+			name: "validation",
+			respond: func(c *gin.Context) {
+				ValidationError(c, map[string]any{"email": "is required"}, "invalid input")
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   ErrValidation,
+			wantDetail: "invalid input",
+			wantErrors: true,
+			wantTitle:  "Unprocessable Entity",
+		},
+		{
+			name:       "bad request with extra",
+			respond:    func(c *gin.Context) { BadRequest(c, "bad input", map[string]any{"field": "name"}) },
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrBadRequest,
+			wantDetail: "bad input",
+			wantExtra:  true,
+			wantTitle:  "Bad Request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/users/42?x=1", nil)
+			c.Writer.Header().Set(RequestIDHeaderKey, "req-1")
+
+			tt.respond(c)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != ProblemContentType {
+				t.Errorf("Content-Type = %q, want %q", ct, ProblemContentType)
+			}
+
+			var p Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if p.Type != "about:blank" || p.Title != tt.wantTitle || p.Status != tt.wantStatus {
+				t.Errorf("problem = (%q, %q, %d), want (about:blank, %q, %d)", p.Type, p.Title, p.Status, tt.wantTitle, tt.wantStatus)
+			}
+			if p.Detail != tt.wantDetail || p.Code != tt.wantCode {
+				t.Errorf("detail, code = (%q, %q), want (%q, %q)", p.Detail, p.Code, tt.wantDetail, tt.wantCode)
+			}
+			if p.Instance != "/users/42" || p.RequestID != "req-1" {
+				t.Errorf("instance, request_id = (%q, %q), want (/users/42, req-1)", p.Instance, p.RequestID)
+			}
+			if (p.Errors != nil) != tt.wantErrors {
+				t.Errorf("errors = %v, want present %v", p.Errors, tt.wantErrors)
+			}
+			if (p.Extra != nil) != tt.wantExtra {
+				t.Errorf("extra = %v, want present %v", p.Extra, tt.wantExtra)
+			}
+		})
+	}
+}
+
+func TestProblemTypeBaseURI(t *testing.T) {
+	withErrorFormat(t, FormatProblem)
+	ProblemTypeBaseURI = "https://errors.example.com/"
+	t.Cleanup(func() { ProblemTypeBaseURI = "" })
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/", nil)
+	Forbidden(c, "no access")
+
+	var p Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if p.Type != "https://errors.example.com/403" {
+		t.Errorf("type = %q, want https://errors.example.com/403", p.Type)
+	}
+}
+
+func TestErrorFormatNegotiation(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      ErrorFormat
+		accept      string
+		wantProblem bool
+	}{
+		{name: "envelope ignores accept", format: FormatEnvelope, accept: ProblemContentType, wantProblem: false},
+		{name: "problem ignores accept", format: FormatProblem, accept: "application/json", wantProblem: true},
+		{name: "negotiate problem", format: FormatNegotiate, accept: "application/problem+json, application/json;q=0.5", wantProblem: true},
+		{name: "negotiate json", format: FormatNegotiate, accept: "application/json", wantProblem: false},
+		{name: "negotiate no accept", format: FormatNegotiate, accept: "", wantProblem: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withErrorFormat(t, tt.format)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/", nil)
+			if tt.accept != "" {
+				c.Request.Header.Set("Accept", tt.accept)
+			}
+			Unauthorized(c, "login required")
+
+			gotProblem := w.Header().Get("Content-Type") == ProblemContentType
+			if gotProblem != tt.wantProblem {
+				t.Errorf("problem = %v, want %v (Content-Type %q)", gotProblem, tt.wantProblem, w.Header().Get("Content-Type"))
+			}
+
+			var body map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if _, hasStatus := body["status"]; hasStatus != tt.wantProblem {
+				t.Errorf("body = %v, want problem %v", body, tt.wantProblem)
+			}
+			if body["code"] != ErrUnauthorized {
+				t.Errorf("code = %v, want %q", body["code"], ErrUnauthorized)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package httputil
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PanicReporter receives a recovered panic value and the stack trace of the panicking goroutine,
+// e.g. to log it or send it to an error tracker.
+type PanicReporter func(c *gin.Context, recovered any, stack []byte)
+
+// DefaultPanicReporter writes the panic and stack to gin.DefaultErrorWriter.
+// It is used by Recovery when no reporter is given.
+var DefaultPanicReporter PanicReporter = func(c *gin.Context, recovered any, stack []byte) {
+	_, _ = fmt.Fprintf(gin.DefaultErrorWriter, "[Recovery] request %s %s (%s): panic: %v\n%s\n",
+		c.Request.Method, c.Request.URL.Path, c.Writer.Header().Get(RequestIDHeaderKey), recovered, stack)
+}
+
+// Recovery returns a middleware that recovers from panics in later handlers.
+// The panic is passed to the reporters and answered with InternalServerError in the standard
+// envelope, or with the status of an apperror.AppError carried by the panic.
+// Panics caused by clients closing the connection are reported but not answered,
+// as the connection can no longer be written to.
+func Recovery(reporters ...PanicReporter) gin.HandlerFunc {
+	if len(reporters) == 0 {
+		reporters = []PanicReporter{DefaultPanicReporter}
+	}
+
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			stack := debug.Stack()
+			for _, report := range reporters {
+				report(c, recovered, stack)
+			}
+
+			err, ok := recovered.(error)
+			if !ok {
+				err = fmt.Errorf("panic: %v", recovered)
+			}
+
+			if isBrokenConnection(err) || c.Writer.Written() {
+				_ = c.Error(err)
+				c.Abort()
+				return
+			}
+
+			// Fail maps AppErrors to their status and everything else to InternalServerError.
+			Fail(c, err)
+			c.Abort()
+		}()
+
+		c.Next()
+	}
+}
+
+// isBrokenConnection reports whether the error means the client went away.
+func isBrokenConnection(err error) bool {
+	return errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, http.ErrAbortHandler)
+}
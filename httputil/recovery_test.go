@@ -0,0 +1,98 @@
+package httputil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/ducconit/gobase/apperror"
+	"github.com/gin-gonic/gin"
+)
+
+func TestRecovery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		panicWith  any
+		wantStatus int
+		wantCode   string
+		wantBody   bool
+	}{
+		{name: "string panic", panicWith: "boom", wantStatus: http.StatusInternalServerError, wantCode: ErrInternalServer, wantBody: true},
+		{name: "error panic", panicWith: errors.New("nil map"), wantStatus: http.StatusInternalServerError, wantCode: ErrInternalServer, wantBody: true},
+		{name: "app error panic", panicWith: fmt.Errorf("load: %w", apperror.ErrNotFound), wantStatus: http.StatusNotFound, wantCode: ErrNotFound, wantBody: true},
+		{name: "broken pipe", panicWith: &os.SyscallError{Syscall: "write", Err: syscall.EPIPE}, wantStatus: http.StatusOK, wantBody: false},
+		{name: "abort handler", panicWith: http.ErrAbortHandler, wantStatus: http.StatusOK, wantBody: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported any
+			var reportedStack string
+
+			r := gin.New()
+			r.Use(RequestID(), Recovery(func(c *gin.Context, recovered any, stack []byte) {
+				reported, reportedStack = recovered, string(stack)
+			}))
+			r.GET("/", func(c *gin.Context) { panic(tt.panicWith) })
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+			if reported != tt.panicWith {
+				t.Errorf("reported = %v, want %v", reported, tt.panicWith)
+			}
+			if !strings.Contains(reportedStack, "recovery_test.go") {
+				t.Errorf("stack does not contain the panicking handler:\n%s", reportedStack)
+			}
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if !tt.wantBody {
+				if w.Body.Len() != 0 {
+					t.Errorf("body = %q, want empty", w.Body.String())
+				}
+				return
+			}
+
+			var resp JsonResponse[any, any]
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if resp.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", resp.Code, tt.wantCode)
+			}
+			if resp.RequestID == "" || resp.RequestID != w.Header().Get(RequestIDHeaderKey) {
+				t.Errorf("request_id = %q, want %q", resp.RequestID, w.Header().Get(RequestIDHeaderKey))
+			}
+			if tt.wantCode == ErrInternalServer && resp.Message != InternalErrorMessage {
+				t.Errorf("message = %q, want %q", resp.Message, InternalErrorMessage)
+			}
+		})
+	}
+}
+
+func TestRecoveryAfterWrite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(Recovery(func(*gin.Context, any, []byte) {}))
+	r.GET("/", func(c *gin.Context) {
+		c.String(http.StatusAccepted, "partial")
+		panic("late")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	if w.Code != http.StatusAccepted || w.Body.String() != "partial" {
+		t.Errorf("response = (%d, %q), want the already written response untouched", w.Code, w.Body.String())
+	}
+}
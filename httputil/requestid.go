@@ -0,0 +1,63 @@
+package httputil
+
+import (
+	"context"
+
+	"github.com/ducconit/gobase/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// MaxRequestIDLength is the maximum length of an incoming request ID that is accepted.
+var MaxRequestIDLength = 128
+
+// requestIDKey is the context.Context key of the request ID.
+type requestIDKey struct{}
+
+// RequestID returns a middleware that assigns every request an ID.
+// A valid incoming RequestIDHeaderKey header is reused so IDs can be traced across services;
+// otherwise a ULID is generated. The ID is set as response header, which the response helpers
+// read, and stored in the request's context.Context for RequestIDFromContext.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeaderKey)
+		if !ValidRequestID(id) {
+			id = utils.GenerateULID()
+		}
+
+		c.Header(RequestIDHeaderKey, id)
+		c.Request = c.Request.WithContext(WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// ValidRequestID reports whether an incoming request ID is safe to reuse: non-empty,
+// at most MaxRequestIDLength long and made of ASCII letters, digits and "-_.:".
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > MaxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or "" if there is none.
+// It works with both the request's context.Context and a *gin.Context.
+func RequestIDFromContext(ctx context.Context) string {
+	if c, ok := ctx.(*gin.Context); ok && c.Request != nil {
+		ctx = c.Request.Context()
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,76 @@
+package httputil
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var fromContext, fromGin string
+	r := gin.New()
+	r.Use(RequestID())
+	r.GET("/", func(c *gin.Context) {
+		fromContext = RequestIDFromContext(c.Request.Context())
+		fromGin = RequestIDFromContext(c)
+		Success(c, "ok", "")
+	})
+
+	tests := []struct {
+		name     string
+		incoming string
+		wantKeep bool
+	}{
+		{name: "no header", incoming: "", wantKeep: false},
+		{name: "valid header", incoming: "edge-01:3f2a.b_9", wantKeep: true},
+		{name: "invalid charset", incoming: "abc<script>", wantKeep: false},
+		{name: "too long", incoming: strings.Repeat("a", MaxRequestIDLength+1), wantKeep: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(RequestIDHeaderKey, tt.incoming)
+			}
+			r.ServeHTTP(w, req)
+
+			id := w.Header().Get(RequestIDHeaderKey)
+			if tt.wantKeep && id != tt.incoming {
+				t.Errorf("header = %q, want %q", id, tt.incoming)
+			}
+			if !tt.wantKeep && (id == tt.incoming || len(id) != 26) {
+				t.Errorf("header = %q, want generated ULID", id)
+			}
+			if fromContext != id || fromGin != id {
+				t.Errorf("RequestIDFromContext() = (%q, %q), want %q", fromContext, fromGin, id)
+			}
+
+			var resp JsonResponse[string, any]
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if resp.RequestID != id {
+				t.Errorf("request_id = %q, want %q", resp.RequestID, id)
+			}
+		})
+	}
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	if got := RequestIDFromContext(context.Background()); got != "" {
+		t.Errorf("RequestIDFromContext() = %q, want empty", got)
+	}
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	if got := RequestIDFromContext(ctx); got != "req-1" {
+		t.Errorf("RequestIDFromContext() = %q, want req-1", got)
+	}
+}
@@ -58,11 +58,20 @@ func SuccessWithExtra[T any, E any](c *gin.Context, data T, extra E, message str
 }
 
 // Error sends an error response with HTTP status code and optional extra data.
+// It writes Problem Details instead of the JsonResponse envelope if WantsProblem reports true.
 func Error[E any](c *gin.Context, httpStatusCode int, errorCode string, errorMessage string, extra ...E) {
 	var extraData E
 	if len(extra) > 0 {
 		extraData = extra[0]
 	}
+	if WantsProblem(c) {
+		p := NewProblem(c, httpStatusCode, errorCode, errorMessage)
+		if len(extra) > 0 {
+			p.Extra = extraData
+		}
+		WriteProblem(c, p)
+		return
+	}
 	resp := JsonResponse[any, E]{
 		Code:      errorCode,
 		Message:   errorMessage,
@@ -73,7 +82,14 @@ func Error[E any](c *gin.Context, httpStatusCode int, errorCode string, errorMes
 }
 
 // ValidationError sends a 422 Unprocessable Entity response with validation errors.
+// In Problem Details mode the validation errors are written to the "errors" member.
 func ValidationError[E map[string]any](c *gin.Context, validationErrors E, message string) {
+	if WantsProblem(c) {
+		p := NewProblem(c, http.StatusUnprocessableEntity, ErrValidation, message)
+		p.Errors = validationErrors
+		WriteProblem(c, p)
+		return
+	}
 	Error(c, http.StatusUnprocessableEntity, ErrValidation, message, validationErrors)
 }
 
@@ -115,8 +131,15 @@ func SimplePagination[T any](c *gin.Context, items []T, total int64, page int, p
 
 // CursorPagination sends HTTP 200 with items and cursor pagination metadata.
 // cursor is the current cursor, nextCursor is the cursor for the next batch.
-// If hasMore is true, nextCursor should be the ID of the last item in data.
+// If hasMore is true, nextCursor should encode the sort keys of the last item in data,
+// e.g. with paginate.CursorCodec.
 func CursorPagination[T any](c *gin.Context, items []T, cursor string, nextCursor string, hasMore bool, message string) {
 	cp := paginate.NewCursorPagination(cursor, nextCursor, hasMore)
 	SuccessWithExtra[[]T, *paginate.CursorPagination](c, items, cp, message)
 }
+
+// CursorPage sends HTTP 200 with items and the bidirectional cursor metadata
+// built by paginate.CursorPage, including prev_cursor and has_previous.
+func CursorPage[T any](c *gin.Context, items []T, cp *paginate.CursorPagination, message string) {
+	SuccessWithExtra[[]T, *paginate.CursorPagination](c, items, cp, message)
+}
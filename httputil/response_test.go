@@ -119,6 +119,7 @@ func TestCursorPagination(t *testing.T) {
 		message    string
 		wantCode   string
 		wantCursor string
+		wantPrev   bool
 	}{
 		{
 			name: "has more items",
@@ -132,6 +133,7 @@ func TestCursorPagination(t *testing.T) {
 			message:    "Items fetched",
 			wantCode:   "0",
 			wantCursor: "cursor1",
+			wantPrev:   true,
 		},
 		{
 			name: "last page",
@@ -145,6 +147,7 @@ func TestCursorPagination(t *testing.T) {
 			message:    "Items fetched",
 			wantCode:   "0",
 			wantCursor: "cursor8",
+			wantPrev:   true,
 		},
 		{
 			name:       "empty items",
@@ -192,9 +195,47 @@ func TestCursorPagination(t *testing.T) {
 				t.Errorf("CursorPagination() hasMore = %v, want %v", resp.Extra.HasMore, tt.hasMore)
 			}
 
+			if resp.Extra.HasPrevious != tt.wantPrev {
+				t.Errorf("CursorPagination() hasPrevious = %v, want %v", resp.Extra.HasPrevious, tt.wantPrev)
+			}
+
 			if len(resp.Data) != len(tt.items) {
 				t.Errorf("CursorPagination() data length = %d, want %d", len(resp.Data), len(tt.items))
 			}
 		})
 	}
 }
+
+func TestCursorPage(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/", nil)
+
+	items, cp, err := paginate.CursorPage([]TestItem{{ID: "6"}, {ID: "5"}, {ID: "4"}}, 2, paginate.Backward, "c7",
+		func(item TestItem) (string, error) { return "c" + item.ID, nil })
+	if err != nil {
+		t.Fatalf("paginate.CursorPage() failed: %v", err)
+	}
+	CursorPage(c, items, cp, "Items fetched")
+
+	var resp JsonResponse[[]TestItem, map[string]any]
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("CursorPage() failed to unmarshal response: %v", err)
+	}
+
+	want := map[string]any{
+		"cursor":       "c7",
+		"next_cursor":  "c6",
+		"prev_cursor":  "c5",
+		"has_more":     true,
+		"has_previous": true,
+	}
+	for k, v := range want {
+		if resp.Extra[k] != v {
+			t.Errorf("CursorPage() extra[%q] = %v, want %v", k, resp.Extra[k], v)
+		}
+	}
+	if len(resp.Data) != 2 || resp.Data[0].ID != "5" || resp.Data[1].ID != "6" {
+		t.Errorf("CursorPage() data = %v, want items 5, 6", resp.Data)
+	}
+}
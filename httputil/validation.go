@@ -0,0 +1,206 @@
+package httputil
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// Messages of BindAndValidate responses.
+var (
+	MessageValidationFailed = "Validation failed"
+	MessageInvalidRequest   = "Invalid request body"
+)
+
+// ValidationMessages maps validation tags to message templates. {field} is replaced with the
+// field name and {param} with the tag parameter. A key of the form "tag.kind", where kind is
+// "string", "slice" or "number", takes precedence over "tag" for fields of that kind.
+var ValidationMessages = map[string]string{
+	"required":    "{field} is required",
+	"required_if": "{field} is required",
+	"email":       "{field} must be a valid email address",
+	"url":         "{field} must be a valid URL",
+	"uuid":        "{field} must be a valid UUID",
+	"numeric":     "{field} must be numeric",
+	"alphanum":    "{field} must contain only letters and digits",
+	"oneof":       "{field} must be one of: {param}",
+	"len":         "{field} must be {param}",
+	"len.string":  "{field} must be {param} characters long",
+	"len.slice":   "{field} must contain {param} items",
+	"min":         "{field} must be at least {param}",
+	"min.string":  "{field} must be at least {param} characters long",
+	"min.slice":   "{field} must contain at least {param} items",
+	"max":         "{field} must be at most {param}",
+	"max.string":  "{field} must be at most {param} characters long",
+	"max.slice":   "{field} must contain at most {param} items",
+	"gt":          "{field} must be greater than {param}",
+	"gte":         "{field} must be greater than or equal to {param}",
+	"lt":          "{field} must be less than {param}",
+	"lte":         "{field} must be less than or equal to {param}",
+	"eqfield":     "{field} must match {param}",
+	"datetime":    "{field} must be a date in the format {param}",
+}
+
+// DefaultValidationMessage is the message of tags missing from ValidationMessages.
+var DefaultValidationMessage = "{field} is invalid"
+
+// ValidationMessageFunc returns the message of a field error, given the field's JSON name.
+// Replace it to plug in translations, e.g. with fe.Translate of a universal-translator.
+var ValidationMessageFunc = ValidationMessage
+
+// ValidationMessage returns the message of a field error from ValidationMessages.
+func ValidationMessage(fe validator.FieldError, field string) string {
+	template, ok := ValidationMessages[fe.Tag()+"."+kindClass(fe.Kind())]
+	if !ok {
+		template, ok = ValidationMessages[fe.Tag()]
+	}
+	if !ok {
+		template = DefaultValidationMessage
+	}
+	return strings.NewReplacer("{field}", field, "{param}", fe.Param()).Replace(template)
+}
+
+// TranslateValidationErrors converts validator errors into the map expected by ValidationError.
+// Fields are keyed by their JSON path in obj, the value that was validated, e.g. "items[2].sku",
+// and the first error of each field is kept. It reports false if err holds no validator errors.
+func TranslateValidationErrors(err error, obj any) (map[string]any, bool) {
+	var ves validator.ValidationErrors
+	if !errors.As(err, &ves) {
+		return nil, false
+	}
+
+	root := reflect.TypeOf(obj)
+	out := make(map[string]any, len(ves))
+	for _, fe := range ves {
+		path := fieldPath(root, fe.StructNamespace())
+		if _, ok := out[path]; ok {
+			continue
+		}
+		out[path] = ValidationMessageFunc(fe, lastSegment(path))
+	}
+	return out, true
+}
+
+// BindAndValidate binds the request into obj with c.ShouldBind, which validates it.
+// On validation errors it sends ValidationError with the translated errors; on malformed
+// input it sends BadRequest. It reports whether binding succeeded.
+func BindAndValidate(c *gin.Context, obj any) bool {
+	err := c.ShouldBind(obj)
+	if err == nil {
+		return true
+	}
+
+	if fields, ok := TranslateValidationErrors(err, obj); ok {
+		ValidationError(c, fields, MessageValidationFailed)
+	} else {
+		BadRequest[any](c, MessageInvalidRequest)
+	}
+	return false
+}
+
+// fieldPath maps a validator struct namespace such as "Order.Items[2].SKU" to the
+// JSON path "items[2].sku", following the struct types from root.
+func fieldPath(root reflect.Type, namespace string) string {
+	segments := strings.Split(namespace, ".")
+	if len(segments) > 1 {
+		segments = segments[1:]
+	}
+
+	t := root
+	parts := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		name, index, _ := strings.Cut(segment, "[")
+		if index != "" {
+			index = "[" + index
+		}
+
+		t = indirect(t)
+		if t == nil || t.Kind() != reflect.Struct {
+			parts = append(parts, name+index)
+			t = nil
+			continue
+		}
+
+		sf, ok := t.FieldByName(name)
+		if !ok {
+			parts = append(parts, name+index)
+			t = nil
+			continue
+		}
+
+		t = sf.Type
+		for range strings.Count(index, "[") {
+			t = elem(t)
+		}
+
+		// Embedded structs are flattened into their parent in JSON.
+		tagName := fieldName(sf)
+		if sf.Anonymous && tagName == "" {
+			continue
+		}
+		if tagName == "" {
+			tagName = sf.Name
+		}
+		parts = append(parts, tagName+index)
+	}
+	return strings.Join(parts, ".")
+}
+
+// fieldName returns the name of a field in its json or form tag.
+func fieldName(sf reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(sf.Tag.Get(key), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return ""
+}
+
+// indirect dereferences pointer types.
+func indirect(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// elem returns the element type of slices, arrays and maps.
+func elem(t reflect.Type) reflect.Type {
+	t = indirect(t)
+	if t == nil {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return t.Elem()
+	}
+	return nil
+}
+
+// lastSegment returns the last element of a field path without index, e.g. "sku" of "items[2].sku".
+func lastSegment(path string) string {
+	if i := strings.LastIndexByte(path, '.'); i >= 0 {
+		path = path[i+1:]
+	}
+	name, _, _ := strings.Cut(path, "[")
+	return name
+}
+
+// kindClass groups kinds for ValidationMessages keys.
+func kindClass(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "slice"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+package httputil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type testAudit struct {
+	CreatedBy string `json:"created_by" binding:"required"`
+}
+
+type testLine struct {
+	SKU      string `json:"sku" binding:"required,alphanum"`
+	Quantity int    `json:"qty" binding:"min=1"`
+}
+
+type testOrder struct {
+	testAudit
+	Email string      `json:"email" binding:"required,email"`
+	Note  string      `json:"note,omitempty" binding:"max=5"`
+	Code  string      `form:"code" binding:"omitempty,len=3"`
+	Items []*testLine `json:"items" binding:"required,min=1,dive"`
+	Plain string      `binding:"oneof=a b"`
+}
+
+func TestTranslateValidationErrors(t *testing.T) {
+	order := testOrder{
+		Email: "not-an-email",
+		Note:  "too long",
+		Code:  "ab",
+		Items: []*testLine{{SKU: "A1", Quantity: 1}, {SKU: "A2", Quantity: 1}, {SKU: "", Quantity: 0}},
+		Plain: "c",
+	}
+
+	v := validator.New()
+	v.SetTagName("binding")
+	err := v.Struct(order)
+
+	got, ok := TranslateValidationErrors(err, &order)
+	if !ok {
+		t.Fatalf("TranslateValidationErrors() ok = false, err = %v", err)
+	}
+
+	want := map[string]any{
+		"created_by":   "created_by is required",
+		"email":        "email must be a valid email address",
+		"note":         "note must be at most 5 characters long",
+		"code":         "code must be 3 characters long",
+		"items[2].sku": "sku is required",
+		"items[2].qty": "qty must be at least 1",
+		"Plain":        "Plain must be one of: a b",
+	}
+	if len(got) != len(want) {
+		t.Errorf("TranslateValidationErrors() = %v, want %v", got, want)
+	}
+	for field, message := range want {
+		if got[field] != message {
+			t.Errorf("TranslateValidationErrors()[%q] = %v, want %q", field, got[field], message)
+		}
+	}
+
+	if _, ok := TranslateValidationErrors(nil, &order); ok {
+		t.Error("TranslateValidationErrors(nil) ok = true, want false")
+	}
+}
+
+func TestValidationMessageFunc(t *testing.T) {
+	ValidationMessageFunc = func(fe validator.FieldError, field string) string {
+		return field + ": " + fe.Tag()
+	}
+	t.Cleanup(func() { ValidationMessageFunc = ValidationMessage })
+
+	v := validator.New()
+	v.SetTagName("binding")
+	order := testOrder{Email: "a@example.com", Items: []*testLine{{SKU: "A1", Quantity: 1}}, Plain: "a"}
+	got, _ := TranslateValidationErrors(v.Struct(order), order)
+
+	if got["created_by"] != "created_by: required" {
+		t.Errorf("TranslateValidationErrors() = %v, want custom message", got)
+	}
+}
+
+func TestBindAndValidate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantOK     bool
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "valid",
+			body:       `{"created_by":"u1","email":"a@example.com","items":[{"sku":"A1","qty":2}],"Plain":"a"}`,
+			wantOK:     true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid",
+			body:       `{"created_by":"u1","email":"a@example.com","items":[{"sku":"A1","qty":0}],"Plain":"a"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   ErrValidation,
+		},
+		{
+			name:       "malformed",
+			body:       `{"email":`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			var order testOrder
+			if ok := BindAndValidate(c, &order); ok != tt.wantOK {
+				t.Fatalf("BindAndValidate() = %v, want %v", ok, tt.wantOK)
+			}
+			if tt.wantOK {
+				return
+			}
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			var resp JsonResponse[any, map[string]any]
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if resp.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", resp.Code, tt.wantCode)
+			}
+			if tt.wantCode == ErrValidation && resp.Extra["items[0].qty"] != "qty must be at least 1" {
+				t.Errorf("extra = %v, want items[0].qty error", resp.Extra)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// jwk is a JSON Web Key (RFC 7517) with the members of the supported key types.
+type jwk struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Curve     string `json:"crv"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	X         string `json:"x"`
+	K         string `json:"k"`
+}
+
+// LoadJWKS reads the verification keys of a JWKS file.
+func LoadJWKS(path string) ([]Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJWKS(data)
+}
+
+// ParseJWKS decodes the verification keys of a JSON Web Key Set.
+// RSA keys become RS256 keys, Ed25519 OKP keys EdDSA keys and symmetric keys HS256 keys.
+// Keys of other types or meant for encryption are skipped, so sets shared with other
+// services load without error.
+func ParseJWKS(data []byte) ([]Key, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("jwt: invalid JWKS: %w", err)
+	}
+
+	keys := make([]Key, 0, len(set.Keys))
+	for _, j := range set.Keys {
+		if j.Use == "enc" {
+			continue
+		}
+
+		k, ok, err := j.key()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if err := k.validate(); err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// key converts the JWK. It reports false for unsupported key types and algorithms.
+func (j jwk) key() (Key, bool, error) {
+	var k Key
+	switch j.KeyType {
+	case "RSA":
+		n, err := encoding.DecodeString(j.N)
+		if err != nil {
+			return k, false, fmt.Errorf("jwt: invalid modulus of key %q", j.KeyID)
+		}
+		e, err := encoding.DecodeString(j.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return k, false, fmt.Errorf("jwt: invalid exponent of key %q", j.KeyID)
+		}
+		k = Key{Algorithm: RS256, Key: &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}}
+	case "OKP":
+		if j.Curve != "Ed25519" {
+			return k, false, nil
+		}
+		x, err := encoding.DecodeString(j.X)
+		if err != nil {
+			return k, false, fmt.Errorf("jwt: invalid public key of key %q", j.KeyID)
+		}
+		k = Key{Algorithm: EdDSA, Key: ed25519.PublicKey(x)}
+	case "oct":
+		secret, err := encoding.DecodeString(j.K)
+		if err != nil {
+			return k, false, fmt.Errorf("jwt: invalid secret of key %q", j.KeyID)
+		}
+		k = Key{Algorithm: HS256, Key: secret}
+	default:
+		return k, false, nil
+	}
+
+	if j.Algorithm != "" && Algorithm(j.Algorithm) != k.Algorithm {
+		return k, false, nil
+	}
+	k.ID = j.KeyID
+	return k, true, nil
+}
@@ -0,0 +1,91 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadJWKS(t *testing.T) {
+	rsaKey := rsaTestKey(t)
+	edKey := ed25519TestKey(t)
+
+	jwks := fmt.Sprintf(`{"keys":[
+		{"kty":"RSA","kid":"rs","alg":"RS256","use":"sig","n":%q,"e":%q},
+		{"kty":"OKP","kid":"ed","crv":"Ed25519","x":%q},
+		{"kty":"oct","kid":"hs","k":%q},
+		{"kty":"EC","kid":"ec","crv":"P-256","x":"AA","y":"AA"},
+		{"kty":"RSA","kid":"enc","use":"enc","n":"AQAB","e":"AQAB"}
+	]}`,
+		encoding.EncodeToString(rsaKey.N.Bytes()),
+		encoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+		encoding.EncodeToString(edKey.Public().(ed25519.PublicKey)),
+		encoding.EncodeToString(testSecret),
+	)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, []byte(jwks), 0o600); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+
+	keys, err := LoadJWKS(path)
+	if err != nil {
+		t.Fatalf("LoadJWKS() failed: %v", err)
+	}
+	if len(keys) != 3 {
+		t.Fatalf("LoadJWKS() returned %d keys, want 3", len(keys))
+	}
+
+	verifier := newTestKeySet(t, "", keys...)
+	if k, _ := verifier.Key("rs"); k.Key.(*rsa.PublicKey).N.Cmp(rsaKey.N) != 0 {
+		t.Error("LoadJWKS() RSA modulus mismatch")
+	}
+
+	exp := NewNumericDate(time.Now().Add(time.Hour))
+	signers := []Key{
+		{ID: "rs", Algorithm: RS256, Key: rsaKey},
+		{ID: "ed", Algorithm: EdDSA, Key: edKey},
+		{ID: "hs", Algorithm: HS256, Key: testSecret},
+	}
+	for _, k := range signers {
+		t.Run(string(k.Algorithm), func(t *testing.T) {
+			token, err := newTestKeySet(t, k.ID, k).Sign(Claims{ExpiresAt: exp})
+			if err != nil {
+				t.Fatalf("Sign() failed: %v", err)
+			}
+			if _, err := (Parser{Keys: verifier}).Parse(token, nil); err != nil {
+				t.Errorf("Parse() error = %v, want token verified by JWKS key", err)
+			}
+		})
+	}
+}
+
+func TestParseJWKSErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		jwks string
+	}{
+		{name: "invalid json", jwks: `{"keys":`},
+		{name: "invalid modulus", jwks: `{"keys":[{"kty":"RSA","n":"!!","e":"AQAB"}]}`},
+		{name: "small modulus", jwks: `{"keys":[{"kty":"RSA","n":"AQAB","e":"AQAB"}]}`},
+		{name: "short Ed25519 key", jwks: `{"keys":[{"kty":"OKP","crv":"Ed25519","x":"AQAB"}]}`},
+		{name: "short secret", jwks: `{"keys":[{"kty":"oct","k":"AQAB"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseJWKS([]byte(tt.jwks)); err == nil {
+				t.Error("ParseJWKS() should return error")
+			}
+		})
+	}
+
+	if _, err := LoadJWKS(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadJWKS() should return error for missing file")
+	}
+}
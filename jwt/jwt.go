@@ -0,0 +1,242 @@
+// Package jwt issues and verifies JSON Web Tokens (RFC 7519) signed with HS256, RS256 or EdDSA,
+// and provides a Gin middleware for bearer token authentication.
+//
+// Keys are held in a KeySet and selected by the "kid" header, so signing keys can be rotated
+// while tokens signed with older keys stay valid. A key only verifies tokens of its own algorithm,
+// which prevents algorithm confusion attacks.
+package jwt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors returned by Parse.
+var (
+	ErrMalformed       = errors.New("jwt: malformed token")
+	ErrUnknownKey      = errors.New("jwt: unknown key")
+	ErrAlgorithm       = errors.New("jwt: unexpected algorithm")
+	ErrSignature       = errors.New("jwt: invalid signature")
+	ErrExpired         = errors.New("jwt: token expired")
+	ErrNotYetValid     = errors.New("jwt: token not valid yet")
+	ErrMissingExpiry   = errors.New("jwt: token has no expiry")
+	ErrInvalidIssuer   = errors.New("jwt: invalid issuer")
+	ErrInvalidAudience = errors.New("jwt: invalid audience")
+	ErrUnsupportedCrit = errors.New("jwt: unsupported critical header")
+)
+
+// errInvalidTimestamp is returned when a NumericDate is not a number.
+var errInvalidTimestamp = errors.New("jwt: invalid timestamp")
+
+// encoding is the unpadded base64url encoding of token segments.
+var encoding = base64.RawURLEncoding
+
+// NumericDate is a JWT timestamp, encoded as seconds since the Unix epoch.
+type NumericDate struct {
+	time.Time
+}
+
+// NewNumericDate returns the timestamp truncated to seconds.
+func NewNumericDate(t time.Time) *NumericDate {
+	return &NumericDate{t.Truncate(time.Second)}
+}
+
+// MarshalJSON encodes the timestamp as integer seconds.
+func (d NumericDate) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, d.Unix(), 10), nil
+}
+
+// UnmarshalJSON decodes integer or fractional seconds.
+func (d *NumericDate) UnmarshalJSON(data []byte) error {
+	f, err := strconv.ParseFloat(string(data), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return errInvalidTimestamp
+	}
+	sec, frac := math.Modf(f)
+	d.Time = time.Unix(int64(sec), int64(frac*1e9))
+	return nil
+}
+
+// Audience is the "aud" claim. It is decoded from a string or an array of strings.
+type Audience []string
+
+// MarshalJSON encodes a single audience as a string, as most consumers expect.
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+// UnmarshalJSON decodes a string or an array of strings.
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// Claims are the registered claims of RFC 7519. Embed it in a struct to add custom claims.
+type Claims struct {
+	Issuer    string       `json:"iss,omitempty"`
+	Subject   string       `json:"sub,omitempty"`
+	Audience  Audience     `json:"aud,omitempty"`
+	ExpiresAt *NumericDate `json:"exp,omitempty"`
+	NotBefore *NumericDate `json:"nbf,omitempty"`
+	IssuedAt  *NumericDate `json:"iat,omitempty"`
+	ID        string       `json:"jti,omitempty"`
+}
+
+// header is the JOSE header.
+type header struct {
+	Algorithm Algorithm `json:"alg"`
+	Type      string    `json:"typ,omitempty"`
+	KeyID     string    `json:"kid,omitempty"`
+	Critical  []string  `json:"crit,omitempty"`
+}
+
+// Sign encodes the claims and signs them with the signing key of the set.
+// claims is any value that encodes to a JSON object, usually a struct embedding Claims.
+func (ks *KeySet) Sign(claims any) (string, error) {
+	k, err := ks.signingKey()
+	if err != nil {
+		return "", err
+	}
+
+	h, err := json.Marshal(header{Algorithm: k.Algorithm, Type: "JWT", KeyID: k.ID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	if !bytes.HasPrefix(payload, []byte("{")) {
+		return "", errors.New("jwt: claims must encode to a JSON object")
+	}
+
+	input := encoding.EncodeToString(h) + "." + encoding.EncodeToString(payload)
+	sig, err := k.sign([]byte(input))
+	if err != nil {
+		return "", err
+	}
+	return input + "." + encoding.EncodeToString(sig), nil
+}
+
+// Parser verifies tokens and validates their registered claims.
+type Parser struct {
+	// Keys holds the verification keys.
+	Keys *KeySet
+
+	// Issuer, when set, must equal the "iss" claim.
+	Issuer string
+
+	// Audience, when set, must be contained in the "aud" claim.
+	Audience string
+
+	// Leeway is the clock skew tolerated when checking "exp" and "nbf".
+	Leeway time.Duration
+
+	// AllowMissingExpiry accepts tokens without an "exp" claim. Such tokens never expire.
+	AllowMissingExpiry bool
+}
+
+// Parse verifies the token and decodes its claims into dst, which may be nil.
+// It returns the registered claims.
+func (p Parser) Parse(token string, dst any) (Claims, error) {
+	return p.parse(time.Now(), token, dst)
+}
+
+// parse verifies a token relative to now.
+func (p Parser) parse(now time.Time, token string, dst any) (Claims, error) {
+	var claims Claims
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, ErrMalformed
+	}
+
+	rawHeader, err := encoding.DecodeString(parts[0])
+	if err != nil {
+		return claims, ErrMalformed
+	}
+	var h header
+	if err := json.Unmarshal(rawHeader, &h); err != nil {
+		return claims, ErrMalformed
+	}
+	if len(h.Critical) > 0 {
+		return claims, ErrUnsupportedCrit
+	}
+
+	k, err := p.Keys.lookup(h.KeyID)
+	if err != nil {
+		return claims, err
+	}
+	if h.Algorithm != k.Algorithm {
+		return claims, fmt.Errorf("%w: %q", ErrAlgorithm, h.Algorithm)
+	}
+
+	sig, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return claims, ErrMalformed
+	}
+	if !k.verify([]byte(parts[0]+"."+parts[1]), sig) {
+		return claims, ErrSignature
+	}
+
+	payload, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, ErrMalformed
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return Claims{}, ErrMalformed
+	}
+	if err := p.validate(now, claims); err != nil {
+		return claims, err
+	}
+
+	if dst != nil {
+		if err := json.Unmarshal(payload, dst); err != nil {
+			return claims, fmt.Errorf("%w: %v", ErrMalformed, err)
+		}
+	}
+	return claims, nil
+}
+
+// validate checks the registered claims at now.
+func (p Parser) validate(now time.Time, c Claims) error {
+	switch {
+	case c.ExpiresAt == nil:
+		if !p.AllowMissingExpiry {
+			return ErrMissingExpiry
+		}
+	case !now.Before(c.ExpiresAt.Add(p.Leeway)):
+		return ErrExpired
+	}
+
+	if c.NotBefore != nil && now.Add(p.Leeway).Before(c.NotBefore.Time) {
+		return ErrNotYetValid
+	}
+	if p.Issuer != "" && c.Issuer != p.Issuer {
+		return ErrInvalidIssuer
+	}
+	if p.Audience != "" && !slices.Contains(c.Audience, p.Audience) {
+		return ErrInvalidAudience
+	}
+	return nil
+}
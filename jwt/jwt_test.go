@@ -0,0 +1,234 @@
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testClaims struct {
+	Claims
+	Role string `json:"role"`
+}
+
+func TestSignParse(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		key  Key
+	}{
+		{name: "HS256", key: Key{ID: "hs", Algorithm: HS256, Key: testSecret}},
+		{name: "RS256", key: Key{ID: "rs", Algorithm: RS256, Key: rsaTestKey(t)}},
+		{name: "EdDSA", key: Key{ID: "ed", Algorithm: EdDSA, Key: ed25519TestKey(t)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks := newTestKeySet(t, tt.key.ID, tt.key)
+
+			token, err := ks.Sign(testClaims{
+				Claims: Claims{
+					Subject:   "user:42",
+					ExpiresAt: NewNumericDate(now.Add(time.Hour)),
+				},
+				Role: "admin",
+			})
+			if err != nil {
+				t.Fatalf("Sign() failed: %v", err)
+			}
+
+			var got testClaims
+			claims, err := Parser{Keys: ks}.Parse(token, &got)
+			if err != nil {
+				t.Fatalf("Parse() failed: %v", err)
+			}
+			if claims.Subject != "user:42" || got.Subject != "user:42" {
+				t.Errorf("Parse() subject = %q, want user:42", claims.Subject)
+			}
+			if got.Role != "admin" {
+				t.Errorf("Parse() role = %q, want admin", got.Role)
+			}
+			if got.ExpiresAt.Unix() != now.Add(time.Hour).Unix() {
+				t.Errorf("Parse() exp = %v, want %v", got.ExpiresAt.Unix(), now.Add(time.Hour).Unix())
+			}
+		})
+	}
+}
+
+func TestParseValidation(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	ks := newTestKeySet(t, "k1", Key{ID: "k1", Algorithm: HS256, Key: testSecret})
+
+	sign := func(c Claims) string {
+		token, err := ks.Sign(c)
+		if err != nil {
+			t.Fatalf("Sign() failed: %v", err)
+		}
+		return token
+	}
+
+	valid := Claims{
+		Issuer:    "auth.example.com",
+		Audience:  Audience{"api", "admin"},
+		ExpiresAt: NewNumericDate(now.Add(time.Minute)),
+		NotBefore: NewNumericDate(now),
+	}
+	expired := valid
+	expired.ExpiresAt = NewNumericDate(now.Add(-10 * time.Second))
+	future := valid
+	future.NotBefore = NewNumericDate(now.Add(10 * time.Second))
+	noExpiry := valid
+	noExpiry.ExpiresAt = nil
+
+	strict := Parser{Keys: ks, Issuer: "auth.example.com", Audience: "api"}
+	lenient := strict
+	lenient.Leeway = 30 * time.Second
+
+	tests := []struct {
+		name    string
+		parser  Parser
+		token   string
+		wantErr error
+	}{
+		{name: "valid", parser: strict, token: sign(valid)},
+		{name: "expired", parser: strict, token: sign(expired), wantErr: ErrExpired},
+		{name: "expired within leeway", parser: lenient, token: sign(expired)},
+		{name: "not yet valid", parser: strict, token: sign(future), wantErr: ErrNotYetValid},
+		{name: "not yet valid within leeway", parser: lenient, token: sign(future)},
+		{name: "missing expiry", parser: strict, token: sign(noExpiry), wantErr: ErrMissingExpiry},
+		{name: "missing expiry allowed", parser: Parser{Keys: ks, AllowMissingExpiry: true}, token: sign(noExpiry)},
+		{name: "wrong issuer", parser: Parser{Keys: ks, Issuer: "other"}, token: sign(valid), wantErr: ErrInvalidIssuer},
+		{name: "wrong audience", parser: Parser{Keys: ks, Audience: "billing"}, token: sign(valid), wantErr: ErrInvalidAudience},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.parser.parse(now, tt.token, nil); !errors.Is(err, tt.wantErr) {
+				t.Errorf("parse() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	exp := NewNumericDate(time.Now().Add(time.Hour))
+	rsaKey := rsaTestKey(t)
+
+	hs := newTestKeySet(t, "k1", Key{ID: "k1", Algorithm: HS256, Key: testSecret})
+	token, err := hs.Sign(Claims{Subject: "user:42", ExpiresAt: exp})
+	if err != nil {
+		t.Fatalf("Sign() failed: %v", err)
+	}
+	parts := strings.Split(token, ".")
+
+	payload, _ := json.Marshal(Claims{Subject: "admin", ExpiresAt: exp})
+	tampered := parts[0] + "." + encoding.EncodeToString(payload) + "." + parts[2]
+
+	// A token signed with HMAC using the RSA public key as secret must not verify against the RSA key.
+	rs := newTestKeySet(t, "", Key{ID: "rs", Algorithm: RS256, Key: &rsaKey.PublicKey})
+	confused := signRaw(t, `{"alg":"HS256","kid":"rs"}`, payload, []byte("public key bytes"))
+
+	tests := []struct {
+		name    string
+		keys    *KeySet
+		token   string
+		wantErr error
+	}{
+		{name: "tampered payload", keys: hs, token: tampered, wantErr: ErrSignature},
+		{name: "algorithm confusion", keys: rs, token: confused, wantErr: ErrAlgorithm},
+		{name: "alg none", keys: hs, token: signRaw(t, `{"alg":"none","kid":"k1"}`, payload, nil), wantErr: ErrAlgorithm},
+		{name: "unknown kid", keys: hs, token: signRaw(t, `{"alg":"HS256","kid":"k9"}`, payload, testSecret), wantErr: ErrUnknownKey},
+		{name: "critical header", keys: hs, token: signRaw(t, `{"alg":"HS256","kid":"k1","crit":["exp"]}`, payload, testSecret), wantErr: ErrUnsupportedCrit},
+		{name: "two segments", keys: hs, token: parts[0] + "." + parts[1], wantErr: ErrMalformed},
+		{name: "invalid header", keys: hs, token: "e30x." + parts[1] + "." + parts[2], wantErr: ErrMalformed},
+		{name: "empty", keys: hs, token: "", wantErr: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := (Parser{Keys: tt.keys}).Parse(tt.token, nil); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	exp := NewNumericDate(time.Now().Add(time.Hour))
+	oldKey := Key{ID: "2024", Algorithm: HS256, Key: testSecret}
+	newKey := Key{ID: "2025", Algorithm: EdDSA, Key: ed25519TestKey(t)}
+
+	old := newTestKeySet(t, "2024", oldKey)
+	rotated := newTestKeySet(t, "2025", oldKey, newKey)
+
+	oldToken, err := old.Sign(Claims{ExpiresAt: exp})
+	if err != nil {
+		t.Fatalf("Sign() failed: %v", err)
+	}
+	if _, err := (Parser{Keys: rotated}).Parse(oldToken, nil); err != nil {
+		t.Errorf("Parse() error = %v, want token of previous key accepted", err)
+	}
+
+	newToken, err := rotated.Sign(Claims{ExpiresAt: exp})
+	if err != nil {
+		t.Fatalf("Sign() failed: %v", err)
+	}
+	if _, err := (Parser{Keys: old}).Parse(newToken, nil); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Parse() error = %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestAudienceJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want Audience
+	}{
+		{name: "string", json: `"api"`, want: Audience{"api"}},
+		{name: "array", json: `["api","admin"]`, want: Audience{"api", "admin"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Audience
+			if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+				t.Fatalf("Unmarshal() failed: %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Unmarshal() = %v, want %v", got, tt.want)
+			}
+
+			encoded, _ := json.Marshal(got)
+			if string(encoded) != tt.json {
+				t.Errorf("Marshal() = %s, want %s", encoded, tt.json)
+			}
+		})
+	}
+}
+
+func TestNumericDateJSON(t *testing.T) {
+	var d NumericDate
+	if err := json.Unmarshal([]byte("1700000000.5"), &d); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	if d.Unix() != 1700000000 || d.Nanosecond() != 500000000 {
+		t.Errorf("Unmarshal() = %v, want 1700000000.5", d.Time)
+	}
+	if err := json.Unmarshal([]byte(`"soon"`), &d); err == nil {
+		t.Error("Unmarshal() should return error for string")
+	}
+}
+
+// signRaw builds an HS256 signed token with an arbitrary header.
+func signRaw(t *testing.T, header string, payload, secret []byte) string {
+	t.Helper()
+	input := encoding.EncodeToString([]byte(header)) + "." + encoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(input))
+	return input + "." + encoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,191 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// minHMACSize is the minimum HS256 secret size in bytes (256 bits, RFC 7518 section 3.2).
+const minHMACSize = 32
+
+// minRSABits is the minimum RSA modulus size (RFC 7518 section 3.3).
+const minRSABits = 2048
+
+// Algorithm is a JWS signing algorithm.
+type Algorithm string
+
+// Supported algorithms.
+const (
+	HS256 Algorithm = "HS256"
+	RS256 Algorithm = "RS256"
+	EdDSA Algorithm = "EdDSA"
+)
+
+// Key is a named signing or verification key.
+type Key struct {
+	// ID is the key ID ("kid") written to and matched against token headers.
+	ID string
+
+	// Algorithm is the only algorithm the key is accepted for.
+	Algorithm Algorithm
+
+	// Key is the key material:
+	//   - HS256: []byte of at least 32 bytes
+	//   - RS256: *rsa.PrivateKey to sign, or *rsa.PublicKey to verify only
+	//   - EdDSA: ed25519.PrivateKey to sign, or ed25519.PublicKey to verify only
+	Key any
+}
+
+// canSign reports whether the key holds signing material.
+func (k Key) canSign() bool {
+	switch k.Key.(type) {
+	case []byte, *rsa.PrivateKey, ed25519.PrivateKey:
+		return true
+	}
+	return false
+}
+
+// validate checks that the key material matches the algorithm.
+func (k Key) validate() error {
+	switch k.Algorithm {
+	case HS256:
+		if secret, ok := k.Key.([]byte); ok && len(secret) >= minHMACSize {
+			return nil
+		}
+		return fmt.Errorf("jwt: key %q must be a []byte of at least %d bytes", k.ID, minHMACSize)
+	case RS256:
+		var pub *rsa.PublicKey
+		switch key := k.Key.(type) {
+		case *rsa.PrivateKey:
+			pub = &key.PublicKey
+		case *rsa.PublicKey:
+			pub = key
+		}
+		if pub != nil && pub.N != nil && pub.N.BitLen() >= minRSABits {
+			return nil
+		}
+		return fmt.Errorf("jwt: key %q must be an RSA key of at least %d bits", k.ID, minRSABits)
+	case EdDSA:
+		switch key := k.Key.(type) {
+		case ed25519.PrivateKey:
+			if len(key) == ed25519.PrivateKeySize {
+				return nil
+			}
+		case ed25519.PublicKey:
+			if len(key) == ed25519.PublicKeySize {
+				return nil
+			}
+		}
+		return fmt.Errorf("jwt: key %q must be an Ed25519 key", k.ID)
+	}
+	return fmt.Errorf("jwt: key %q has unsupported algorithm %q", k.ID, k.Algorithm)
+}
+
+// sign returns the signature of the signing input.
+func (k Key) sign(input []byte) ([]byte, error) {
+	switch key := k.Key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, key)
+		mac.Write(input)
+		return mac.Sum(nil), nil
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256(input)
+		return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	case ed25519.PrivateKey:
+		return ed25519.Sign(key, input), nil
+	}
+	return nil, fmt.Errorf("jwt: key %q cannot sign", k.ID)
+}
+
+// verify reports whether sig is a valid signature of the signing input.
+func (k Key) verify(input, sig []byte) bool {
+	switch key := k.Key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, key)
+		mac.Write(input)
+		return hmac.Equal(sig, mac.Sum(nil))
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256(input)
+		return rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig) == nil
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(input)
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+	case ed25519.PrivateKey:
+		return ed25519.Verify(key.Public().(ed25519.PublicKey), input, sig)
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, input, sig)
+	}
+	return false
+}
+
+// KeySet holds the keys used to sign and verify tokens, looked up by key ID.
+type KeySet struct {
+	signing string
+	keys    map[string]Key
+}
+
+// NewKeySet creates a key set that signs with the key named signing.
+// signing may be empty for sets that only verify, e.g. keys loaded from a JWKS file.
+func NewKeySet(signing string, keys ...Key) (*KeySet, error) {
+	ks := &KeySet{
+		signing: signing,
+		keys:    make(map[string]Key, len(keys)),
+	}
+
+	for _, k := range keys {
+		if _, ok := ks.keys[k.ID]; ok {
+			return nil, fmt.Errorf("jwt: duplicate key id %q", k.ID)
+		}
+		if err := k.validate(); err != nil {
+			return nil, err
+		}
+		ks.keys[k.ID] = k
+	}
+
+	if signing != "" {
+		k, ok := ks.keys[signing]
+		if !ok {
+			return nil, fmt.Errorf("%w: signing key %q", ErrUnknownKey, signing)
+		}
+		if !k.canSign() {
+			return nil, fmt.Errorf("jwt: signing key %q is a public key", signing)
+		}
+	}
+	return ks, nil
+}
+
+// Key returns the key with the ID.
+func (ks *KeySet) Key(id string) (Key, bool) {
+	k, ok := ks.keys[id]
+	return k, ok
+}
+
+// signingKey returns the key used by Sign.
+func (ks *KeySet) signingKey() (Key, error) {
+	if ks.signing == "" {
+		return Key{}, errors.New("jwt: key set has no signing key")
+	}
+	return ks.keys[ks.signing], nil
+}
+
+// lookup returns the key named by a token header.
+// A header without kid is accepted only if the set holds a single key.
+func (ks *KeySet) lookup(id string) (Key, error) {
+	if id == "" && len(ks.keys) == 1 {
+		for _, k := range ks.keys {
+			return k, nil
+		}
+	}
+
+	k, ok := ks.keys[id]
+	if !ok {
+		return Key{}, fmt.Errorf("%w: %q", ErrUnknownKey, id)
+	}
+	return k, nil
+}
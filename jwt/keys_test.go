@@ -0,0 +1,103 @@
+package jwt
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"sync"
+	"testing"
+)
+
+var (
+	testSecret = bytes.Repeat([]byte("s"), minHMACSize)
+
+	testRSAOnce sync.Once
+	testRSAKey  *rsa.PrivateKey
+)
+
+// rsaTestKey returns a shared RSA key, as generating one is slow.
+func rsaTestKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	testRSAOnce.Do(func() {
+		key, err := rsa.GenerateKey(rand.Reader, minRSABits)
+		if err != nil {
+			t.Fatalf("rsa.GenerateKey() failed: %v", err)
+		}
+		testRSAKey = key
+	})
+	return testRSAKey
+}
+
+func ed25519TestKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() failed: %v", err)
+	}
+	return key
+}
+
+func newTestKeySet(t *testing.T, signing string, keys ...Key) *KeySet {
+	t.Helper()
+	ks, err := NewKeySet(signing, keys...)
+	if err != nil {
+		t.Fatalf("NewKeySet() failed: %v", err)
+	}
+	return ks
+}
+
+func TestNewKeySetErrors(t *testing.T) {
+	rsaKey := rsaTestKey(t)
+	edKey := ed25519TestKey(t)
+
+	smallRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		signing string
+		keys    []Key
+	}{
+		{name: "short secret", signing: "k1", keys: []Key{{ID: "k1", Algorithm: HS256, Key: []byte("short")}}},
+		{name: "small RSA key", signing: "k1", keys: []Key{{ID: "k1", Algorithm: RS256, Key: smallRSA}}},
+		{name: "algorithm mismatch", signing: "k1", keys: []Key{{ID: "k1", Algorithm: HS256, Key: rsaKey}}},
+		{name: "unsupported algorithm", signing: "k1", keys: []Key{{ID: "k1", Algorithm: "none", Key: testSecret}}},
+		{name: "duplicate id", signing: "k1", keys: []Key{{ID: "k1", Algorithm: HS256, Key: testSecret}, {ID: "k1", Algorithm: EdDSA, Key: edKey}}},
+		{name: "unknown signing key", signing: "k2", keys: []Key{{ID: "k1", Algorithm: HS256, Key: testSecret}}},
+		{name: "public signing key", signing: "k1", keys: []Key{{ID: "k1", Algorithm: EdDSA, Key: edKey.Public()}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewKeySet(tt.signing, tt.keys...); err == nil {
+				t.Error("NewKeySet() should return error")
+			}
+		})
+	}
+}
+
+func TestKeySetLookup(t *testing.T) {
+	single := newTestKeySet(t, "", Key{ID: "k1", Algorithm: HS256, Key: testSecret})
+	if k, err := single.lookup(""); err != nil || k.ID != "k1" {
+		t.Errorf("lookup(\"\") = (%q, %v), want single key", k.ID, err)
+	}
+
+	multi := newTestKeySet(t, "",
+		Key{ID: "k1", Algorithm: HS256, Key: testSecret},
+		Key{ID: "k2", Algorithm: EdDSA, Key: ed25519TestKey(t)},
+	)
+	if _, err := multi.lookup(""); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("lookup(\"\") error = %v, want ErrUnknownKey", err)
+	}
+	if k, ok := multi.Key("k2"); !ok || k.Algorithm != EdDSA {
+		t.Errorf("Key(k2) = (%v, %v), want EdDSA key", k.Algorithm, ok)
+	}
+
+	if _, err := multi.Sign(Claims{}); err == nil {
+		t.Error("Sign() should return error without signing key")
+	}
+}
@@ -0,0 +1,77 @@
+package jwt
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ducconit/gobase/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+// ClaimsContextKey is the Gin context key the middleware stores claims under.
+var ClaimsContextKey = "jwt_claims"
+
+// Messages of the middleware responses.
+var (
+	MessageMissingToken = "Missing bearer token"
+	MessageInvalidToken = "Invalid token"
+	MessageExpiredToken = "Token expired"
+	MessageForbidden    = "Forbidden"
+)
+
+// Middleware authenticates requests with a bearer token in the Authorization header.
+// The token's claims are decoded into a T, usually a struct embedding Claims, and stored in the
+// context for FromContext. Missing or invalid tokens are answered with httputil.Unauthorized.
+// If authorize is given and returns false, the request is answered with httputil.Forbidden.
+func Middleware[T any](p Parser, authorize ...func(c *gin.Context, claims T) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			httputil.Unauthorized(c, MessageMissingToken)
+			c.Abort()
+			return
+		}
+
+		var claims T
+		if _, err := p.Parse(token, &claims); err != nil {
+			message := MessageInvalidToken
+			if errors.Is(err, ErrExpired) {
+				message = MessageExpiredToken
+			}
+			httputil.Unauthorized(c, message)
+			c.Abort()
+			return
+		}
+
+		c.Set(ClaimsContextKey, claims)
+		for _, allow := range authorize {
+			if !allow(c, claims) {
+				httputil.Forbidden(c, MessageForbidden)
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// FromContext returns the claims stored by Middleware.
+func FromContext[T any](c *gin.Context) (T, bool) {
+	claims, ok := c.Get(ClaimsContextKey)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	t, ok := claims.(T)
+	return t, ok
+}
+
+// bearerToken extracts the token of an "Authorization: Bearer <token>" header.
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
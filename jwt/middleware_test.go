@@ -0,0 +1,82 @@
+package jwt
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ducconit/gobase/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ks := newTestKeySet(t, "k1", Key{ID: "k1", Algorithm: HS256, Key: testSecret})
+	sign := func(role string, exp time.Time) string {
+		token, err := ks.Sign(testClaims{Claims: Claims{Subject: "user:42", ExpiresAt: NewNumericDate(exp)}, Role: role})
+		if err != nil {
+			t.Fatalf("Sign() failed: %v", err)
+		}
+		return token
+	}
+
+	r := gin.New()
+	r.GET("/", Middleware(Parser{Keys: ks}, func(c *gin.Context, claims testClaims) bool {
+		return claims.Role == "admin"
+	}), func(c *gin.Context) {
+		claims, ok := FromContext[testClaims](c)
+		if !ok {
+			httputil.InternalServerError(c, "no claims")
+			return
+		}
+		httputil.Success(c, claims.Subject, "")
+	})
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantCode      string
+		wantMessage   string
+	}{
+		{name: "valid", authorization: "Bearer " + sign("admin", time.Now().Add(time.Hour)), wantStatus: http.StatusOK, wantCode: httputil.ErrNone},
+		{name: "lowercase scheme", authorization: "bearer " + sign("admin", time.Now().Add(time.Hour)), wantStatus: http.StatusOK, wantCode: httputil.ErrNone},
+		{name: "missing", authorization: "", wantStatus: http.StatusUnauthorized, wantCode: httputil.ErrUnauthorized, wantMessage: MessageMissingToken},
+		{name: "basic auth", authorization: "Basic dXNlcjpwYXNz", wantStatus: http.StatusUnauthorized, wantCode: httputil.ErrUnauthorized, wantMessage: MessageMissingToken},
+		{name: "invalid", authorization: "Bearer not.a.token", wantStatus: http.StatusUnauthorized, wantCode: httputil.ErrUnauthorized, wantMessage: MessageInvalidToken},
+		{name: "expired", authorization: "Bearer " + sign("admin", time.Now().Add(-time.Hour)), wantStatus: http.StatusUnauthorized, wantCode: httputil.ErrUnauthorized, wantMessage: MessageExpiredToken},
+		{name: "forbidden", authorization: "Bearer " + sign("viewer", time.Now().Add(time.Hour)), wantStatus: http.StatusForbidden, wantCode: httputil.ErrForbidden, wantMessage: MessageForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			var resp httputil.JsonResponse[string, any]
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if resp.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", resp.Code, tt.wantCode)
+			}
+			if tt.wantStatus == http.StatusOK && resp.Data != "user:42" {
+				t.Errorf("data = %q, want user:42", resp.Data)
+			}
+			if resp.Message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", resp.Message, tt.wantMessage)
+			}
+		})
+	}
+}
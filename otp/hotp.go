@@ -0,0 +1,121 @@
+package otp
+
+import (
+	"crypto/hmac"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ducconit/gobase/utils"
+)
+
+// maxDigits is the largest code length a 31-bit HOTP value can fill.
+const maxDigits = 10
+
+// HOTP generates and verifies counter-based one-time passwords (RFC 4226).
+type HOTP struct {
+	// Secret is the base32 encoded shared secret.
+	Secret string
+
+	// Digits is the code length. Zero means utils.OTPLength.
+	Digits int
+
+	// Algorithm is the HMAC hash function. The zero value is SHA1.
+	Algorithm Algorithm
+}
+
+// Generate returns the code for the counter.
+func (h HOTP) Generate(counter uint64) (string, error) {
+	key, err := DecodeSecret(h.Secret)
+	if err != nil {
+		return "", err
+	}
+
+	digits, err := codeDigits(h.Digits)
+	if err != nil {
+		return "", err
+	}
+
+	return generate(key, counter, digits, h.Algorithm), nil
+}
+
+// Verify checks the code against the counters from counter to counter+lookAhead,
+// which tolerates codes generated on the device without reaching the server.
+// On success it returns the counter to store for the next verification.
+func (h HOTP) Verify(code string, counter uint64, lookAhead int) (next uint64, ok bool) {
+	key, err := DecodeSecret(h.Secret)
+	if err != nil {
+		return counter, false
+	}
+
+	digits, err := codeDigits(h.Digits)
+	if err != nil || len(code) != digits {
+		return counter, false
+	}
+
+	for i := uint64(0); i <= uint64(max(lookAhead, 0)); i++ {
+		if equal(generate(key, counter+i, digits, h.Algorithm), code) {
+			return counter + i + 1, true
+		}
+	}
+	return counter, false
+}
+
+// URI returns the otpauth:// provisioning URI for authenticator apps, usually shown as QR code.
+func (h HOTP) URI(issuer, account string, counter uint64) string {
+	digits, _ := codeDigits(h.Digits)
+
+	params := url.Values{}
+	params.Set("counter", strconv.FormatUint(counter, 10))
+	return provisioningURI("hotp", issuer, account, h.Secret, digits, h.Algorithm, params)
+}
+
+// generate computes the HOTP value of the counter, truncated to digits.
+func generate(key []byte, counter uint64, digits int, alg Algorithm) string {
+	mac := hmac.New(alg.hash(), key)
+	_ = binary.Write(mac, binary.BigEndian, counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint64(1)
+	for range digits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, uint64(value)%mod)
+}
+
+// codeDigits resolves the code length, defaulting to utils.OTPLength.
+func codeDigits(digits int) (int, error) {
+	if digits == 0 {
+		digits = utils.OTPLength
+	}
+	if digits < 1 || digits > maxDigits {
+		return 0, fmt.Errorf("otp: digits must be between 1 and %d", maxDigits)
+	}
+	return digits, nil
+}
+
+// equal compares codes in constant time.
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// provisioningURI builds an otpauth:// URI in the Key Uri Format used by authenticator apps.
+func provisioningURI(kind, issuer, account, secret string, digits int, alg Algorithm, params url.Values) string {
+	label := url.PathEscape(account)
+	if issuer != "" {
+		label = url.PathEscape(issuer) + ":" + label
+		params.Set("issuer", issuer)
+	}
+
+	params.Set("secret", strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "=")))
+	params.Set("algorithm", alg.String())
+	params.Set("digits", strconv.Itoa(digits))
+
+	return "otpauth://" + kind + "/" + label + "?" + strings.ReplaceAll(params.Encode(), "+", "%20")
+}
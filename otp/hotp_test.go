@@ -0,0 +1,108 @@
+package otp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ducconit/gobase/utils"
+)
+
+// rfc4226Secret is the test secret "12345678901234567890" from RFC 4226 Appendix D.
+var rfc4226Secret = EncodeSecret([]byte("12345678901234567890"))
+
+func TestHOTPGenerateRFC4226(t *testing.T) {
+	want := []string{"755224", "287082", "359152", "969429", "338314", "254676", "287922", "162583", "399871", "520489"}
+	h := HOTP{Secret: rfc4226Secret, Digits: 6}
+
+	for counter, code := range want {
+		got, err := h.Generate(uint64(counter))
+		if err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+		if got != code {
+			t.Errorf("Generate(%d) = %s, want %s", counter, got, code)
+		}
+	}
+}
+
+func TestHOTPDefaultDigits(t *testing.T) {
+	old := utils.OTPLength
+	utils.OTPLength = 8
+	defer func() { utils.OTPLength = old }()
+
+	got, err := HOTP{Secret: rfc4226Secret}.Generate(0)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	if len(got) != 8 {
+		t.Errorf("Generate() = %s, want %d digits", got, 8)
+	}
+}
+
+func TestHOTPGenerateErrors(t *testing.T) {
+	if _, err := (HOTP{Secret: "not base32!"}).Generate(0); err != ErrInvalidSecret {
+		t.Errorf("Generate() error = %v, want ErrInvalidSecret", err)
+	}
+	if _, err := (HOTP{Secret: rfc4226Secret, Digits: 11}).Generate(0); err == nil {
+		t.Error("Generate() should return error for too many digits")
+	}
+}
+
+func TestHOTPVerify(t *testing.T) {
+	h := HOTP{Secret: rfc4226Secret, Digits: 6}
+
+	tests := []struct {
+		name      string
+		code      string
+		counter   uint64
+		lookAhead int
+		wantNext  uint64
+		wantOK    bool
+	}{
+		{name: "exact counter", code: "755224", counter: 0, lookAhead: 0, wantNext: 1, wantOK: true},
+		{name: "within look ahead", code: "969429", counter: 0, lookAhead: 3, wantNext: 4, wantOK: true},
+		{name: "beyond look ahead", code: "338314", counter: 0, lookAhead: 3, wantNext: 0, wantOK: false},
+		{name: "already used", code: "755224", counter: 1, lookAhead: 3, wantNext: 1, wantOK: false},
+		{name: "wrong length", code: "75522", counter: 0, lookAhead: 3, wantNext: 0, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, ok := h.Verify(tt.code, tt.counter, tt.lookAhead)
+			if next != tt.wantNext || ok != tt.wantOK {
+				t.Errorf("Verify() = (%d, %v), want (%d, %v)", next, ok, tt.wantNext, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestHOTPURI(t *testing.T) {
+	uri := HOTP{Secret: "jbsw y3dp ehpk 3pxp", Digits: 6}.URI("Go Base", "alice@example.com", 5)
+
+	want := "otpauth://hotp/Go%20Base:alice@example.com?algorithm=SHA1&counter=5&digits=6&issuer=Go%20Base&secret=JBSWY3DPEHPK3PXP"
+	if uri != want {
+		t.Errorf("URI() = %s, want %s", uri, want)
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() failed: %v", err)
+	}
+	if strings.Contains(secret, "=") {
+		t.Errorf("GenerateSecret() = %s, want unpadded base32", secret)
+	}
+
+	key, err := DecodeSecret(strings.ToLower(secret))
+	if err != nil {
+		t.Fatalf("DecodeSecret() failed: %v", err)
+	}
+	if len(key) != DefaultSecretSize {
+		t.Errorf("DecodeSecret() length = %d, want %d", len(key), DefaultSecretSize)
+	}
+
+	if _, err := GenerateSecret(0); err == nil {
+		t.Error("GenerateSecret() should return error for zero size")
+	}
+}
@@ -0,0 +1,93 @@
+// Package otp implements HOTP (RFC 4226) and TOTP (RFC 6238) one-time passwords
+// compatible with authenticator apps.
+package otp
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"errors"
+	"hash"
+	"strings"
+)
+
+// DefaultSecretSize is the default secret size in bytes (160 bits, as recommended by RFC 4226).
+const DefaultSecretSize = 20
+
+// ErrInvalidSecret is returned when a secret is not valid base32.
+var ErrInvalidSecret = errors.New("otp: invalid secret")
+
+// secretEncoding is unpadded base32, the encoding authenticator apps expect.
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Algorithm is the HMAC hash function used to generate codes.
+type Algorithm int
+
+const (
+	// SHA1 is the default algorithm and the only one supported by every authenticator app.
+	SHA1 Algorithm = iota
+	SHA256
+	SHA512
+)
+
+// String returns the algorithm name used in provisioning URIs.
+func (a Algorithm) String() string {
+	switch a {
+	case SHA256:
+		return "SHA256"
+	case SHA512:
+		return "SHA512"
+	default:
+		return "SHA1"
+	}
+}
+
+// hash returns the hash constructor of the algorithm.
+func (a Algorithm) hash() func() hash.Hash {
+	switch a {
+	case SHA256:
+		return sha256.New
+	case SHA512:
+		return sha512.New
+	default:
+		return sha1.New
+	}
+}
+
+// GenerateSecret generates a random base32 encoded secret of the given size in bytes.
+// If no size is specified, DefaultSecretSize is used.
+func GenerateSecret(size ...int) (string, error) {
+	n := DefaultSecretSize
+	if len(size) > 0 {
+		n = size[0]
+	}
+	if n <= 0 {
+		return "", errors.New("otp: secret size must be greater than 0")
+	}
+
+	secret := make([]byte, n)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return EncodeSecret(secret), nil
+}
+
+// EncodeSecret encodes a raw secret as unpadded base32.
+func EncodeSecret(secret []byte) string {
+	return secretEncoding.EncodeToString(secret)
+}
+
+// DecodeSecret decodes a base32 secret. It ignores case, spaces and padding,
+// as secrets are often typed in by hand.
+func DecodeSecret(secret string) ([]byte, error) {
+	s := strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	s = strings.TrimRight(s, "=")
+
+	key, err := secretEncoding.DecodeString(s)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
@@ -0,0 +1,109 @@
+package otp
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DefaultPeriod is the default TOTP time step, used by all common authenticator apps.
+const DefaultPeriod = 30 * time.Second
+
+// ReplayFunc is called with the time step of a matching code before it is accepted.
+// It returns false if the step was already used, which rejects the code.
+// Implementations typically store the last accepted step per user and only allow later ones.
+type ReplayFunc func(step uint64) bool
+
+// TOTP generates and verifies time-based one-time passwords (RFC 6238).
+type TOTP struct {
+	// Secret is the base32 encoded shared secret.
+	Secret string
+
+	// Digits is the code length. Zero means utils.OTPLength.
+	Digits int
+
+	// Algorithm is the HMAC hash function. The zero value is SHA1.
+	Algorithm Algorithm
+
+	// Period is the time step. Zero means DefaultPeriod.
+	Period time.Duration
+
+	// Skew is the number of time steps before and after the current one that are accepted,
+	// to tolerate clock drift and slow typing. 1 is a common choice.
+	Skew int
+
+	// Replay, when set, is consulted before accepting a code to prevent its reuse.
+	Replay ReplayFunc
+}
+
+// Step returns the time step containing the time.
+func (t TOTP) Step(at time.Time) uint64 {
+	unix := at.Unix()
+	if unix < 0 {
+		return 0
+	}
+	return uint64(unix) / uint64(t.period().Seconds())
+}
+
+// Generate returns the code for the time.
+func (t TOTP) Generate(at time.Time) (string, error) {
+	return t.hotp().Generate(t.Step(at))
+}
+
+// Now returns the code for the current time.
+func (t TOTP) Now() (string, error) {
+	return t.Generate(time.Now())
+}
+
+// Verify checks the code against the time step of at and Skew steps around it.
+// A matching code is rejected if Replay reports its step as already used.
+func (t TOTP) Verify(code string, at time.Time) bool {
+	key, err := DecodeSecret(t.Secret)
+	if err != nil {
+		return false
+	}
+
+	digits, err := codeDigits(t.Digits)
+	if err != nil || len(code) != digits {
+		return false
+	}
+
+	current := t.Step(at)
+	skew := uint64(max(t.Skew, 0))
+	for i := uint64(0); i <= skew; i++ {
+		steps := []uint64{current + i}
+		if i > 0 && i <= current {
+			steps = append(steps, current-i)
+		}
+
+		for _, step := range steps {
+			if !equal(generate(key, step, digits, t.Algorithm), code) {
+				continue
+			}
+			return t.Replay == nil || t.Replay(step)
+		}
+	}
+	return false
+}
+
+// URI returns the otpauth:// provisioning URI for authenticator apps, usually shown as QR code.
+func (t TOTP) URI(issuer, account string) string {
+	digits, _ := codeDigits(t.Digits)
+
+	params := url.Values{}
+	params.Set("period", strconv.Itoa(int(t.period().Seconds())))
+	return provisioningURI("totp", issuer, account, t.Secret, digits, t.Algorithm, params)
+}
+
+// period resolves the time step, defaulting to DefaultPeriod.
+func (t TOTP) period() time.Duration {
+	if t.Period < time.Second {
+		return DefaultPeriod
+	}
+	return t.Period
+}
+
+// hotp returns the HOTP generator sharing the TOTP settings.
+func (t TOTP) hotp() HOTP {
+	return HOTP{Secret: t.Secret, Digits: t.Digits, Algorithm: t.Algorithm}
+}
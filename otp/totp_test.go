@@ -0,0 +1,135 @@
+package otp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTOTPGenerateRFC6238(t *testing.T) {
+	secrets := map[Algorithm]string{
+		SHA1:   EncodeSecret([]byte("12345678901234567890")),
+		SHA256: EncodeSecret([]byte("12345678901234567890123456789012")),
+		SHA512: EncodeSecret([]byte("1234567890123456789012345678901234567890123456789012345678901234")),
+	}
+
+	tests := []struct {
+		unix int64
+		alg  Algorithm
+		want string
+	}{
+		{unix: 59, alg: SHA1, want: "94287082"},
+		{unix: 59, alg: SHA256, want: "46119246"},
+		{unix: 59, alg: SHA512, want: "90693936"},
+		{unix: 1111111109, alg: SHA1, want: "07081804"},
+		{unix: 1111111109, alg: SHA256, want: "68084774"},
+		{unix: 1111111109, alg: SHA512, want: "25091201"},
+		{unix: 2000000000, alg: SHA1, want: "69279037"},
+		{unix: 20000000000, alg: SHA512, want: "47863826"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alg.String(), func(t *testing.T) {
+			totp := TOTP{Secret: secrets[tt.alg], Digits: 8, Algorithm: tt.alg}
+			got, err := totp.Generate(time.Unix(tt.unix, 0))
+			if err != nil {
+				t.Fatalf("Generate() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Generate(%d) = %s, want %s", tt.unix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTOTPVerifySkew(t *testing.T) {
+	totp := TOTP{Secret: rfc4226Secret, Digits: 6, Skew: 1}
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name   string
+		offset time.Duration
+		want   bool
+	}{
+		{name: "current step", offset: 0, want: true},
+		{name: "previous step", offset: -30 * time.Second, want: true},
+		{name: "next step", offset: 30 * time.Second, want: true},
+		{name: "two steps ago", offset: -60 * time.Second, want: false},
+		{name: "two steps ahead", offset: 60 * time.Second, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := totp.Generate(now.Add(tt.offset))
+			if err != nil {
+				t.Fatalf("Generate() failed: %v", err)
+			}
+			if got := totp.Verify(code, now); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	strict := totp
+	strict.Skew = 0
+	code, _ := totp.Generate(now.Add(-30 * time.Second))
+	if strict.Verify(code, now) {
+		t.Error("Verify() without skew should reject previous step")
+	}
+}
+
+func TestTOTPVerifyReplay(t *testing.T) {
+	var lastStep uint64
+	totp := TOTP{
+		Secret: rfc4226Secret,
+		Digits: 6,
+		Skew:   1,
+		Replay: func(step uint64) bool {
+			if step <= lastStep {
+				return false
+			}
+			lastStep = step
+			return true
+		},
+	}
+	now := time.Unix(1700000000, 0)
+
+	code, err := totp.Generate(now)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	if !totp.Verify(code, now) {
+		t.Fatal("Verify() should accept first use")
+	}
+	if lastStep != totp.Step(now) {
+		t.Errorf("Replay step = %d, want %d", lastStep, totp.Step(now))
+	}
+	if totp.Verify(code, now.Add(10*time.Second)) {
+		t.Error("Verify() should reject replayed code")
+	}
+
+	previous, _ := totp.Generate(now.Add(-30 * time.Second))
+	if totp.Verify(previous, now) {
+		t.Error("Verify() should reject code older than the last used one")
+	}
+}
+
+func TestTOTPVerifyInvalid(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	if (TOTP{Secret: "!!!", Digits: 6}).Verify("123456", now) {
+		t.Error("Verify() should return false for invalid secret")
+	}
+	if (TOTP{Secret: rfc4226Secret, Digits: 6}).Verify("12345", now) {
+		t.Error("Verify() should return false for wrong code length")
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTP{Secret: "JBSWY3DPEHPK3PXP", Digits: 6}.URI("Example", "alice")
+
+	want := "otpauth://totp/Example:alice?algorithm=SHA1&digits=6&issuer=Example&period=30&secret=JBSWY3DPEHPK3PXP"
+	if uri != want {
+		t.Errorf("URI() = %s, want %s", uri, want)
+	}
+}
@@ -0,0 +1,142 @@
+package paginate
+
+import "fmt"
+
+// Edge is an item of a Connection with its cursor.
+type Edge[T any] struct {
+	// Cursor is the cursor of the node, usable as after or before argument.
+	Cursor string `json:"cursor"`
+
+	// Node is the item.
+	Node T `json:"node"`
+}
+
+// PageInfo is the page metadata of a Connection.
+type PageInfo struct {
+	// HasNextPage indicates whether there are more items after the last edge.
+	HasNextPage bool `json:"hasNextPage"`
+
+	// HasPreviousPage indicates whether there are items before the first edge.
+	HasPreviousPage bool `json:"hasPreviousPage"`
+
+	// StartCursor is the cursor of the first edge, nil if there are no edges.
+	StartCursor *string `json:"startCursor"`
+
+	// EndCursor is the cursor of the last edge, nil if there are no edges.
+	EndCursor *string `json:"endCursor"`
+}
+
+// Connection is a page of items in the shape of the GraphQL Cursor Connections Specification (Relay).
+type Connection[T any] struct {
+	// Edges are the items of the page with their cursors.
+	Edges []Edge[T] `json:"edges"`
+
+	// PageInfo is the page metadata.
+	PageInfo PageInfo `json:"pageInfo"`
+}
+
+// Nodes returns the items of the connection.
+func (c *Connection[T]) Nodes() []T {
+	nodes := make([]T, len(c.Edges))
+	for i, e := range c.Edges {
+		nodes[i] = e.Node
+	}
+	return nodes
+}
+
+// NewConnection creates a connection from the items and cursor metadata of a page,
+// e.g. as returned by CursorPage. cursorOf encodes the cursor of each edge.
+func NewConnection[T any](items []T, cp *CursorPagination, cursorOf func(T) (string, error)) (*Connection[T], error) {
+	conn := &Connection[T]{
+		Edges: make([]Edge[T], len(items)),
+		PageInfo: PageInfo{
+			HasNextPage:     cp.HasMore,
+			HasPreviousPage: cp.HasPrevious,
+		},
+	}
+
+	for i, item := range items {
+		cursor, err := cursorOf(item)
+		if err != nil {
+			return nil, err
+		}
+		conn.Edges[i] = Edge[T]{Cursor: cursor, Node: item}
+	}
+
+	if len(conn.Edges) > 0 {
+		conn.PageInfo.StartCursor = &conn.Edges[0].Cursor
+		conn.PageInfo.EndCursor = &conn.Edges[len(conn.Edges)-1].Cursor
+	}
+	return conn, nil
+}
+
+// ConnectionFromRows trims and orders rows fetched with limit+1 like CursorPage,
+// and returns them as a connection. A limit of 0, as for first: 0, returns no edges.
+func ConnectionFromRows[T any](rows []T, limit int, direction Direction, cursor string, cursorOf func(T) (string, error)) (*Connection[T], error) {
+	if limit == 0 {
+		return NewConnection([]T{}, &CursorPagination{Cursor: cursor}, cursorOf)
+	}
+
+	items, cp, err := CursorPage(rows, limit, direction, cursor, cursorOf)
+	if err != nil {
+		return nil, err
+	}
+	return NewConnection(items, cp, cursorOf)
+}
+
+// ConnectionArgs are the Relay pagination arguments of a connection field.
+type ConnectionArgs struct {
+	First  *int
+	After  *string
+	Last   *int
+	Before *string
+}
+
+// Params converts the arguments into pagination parameters. Paging forward uses first and after,
+// paging backward last and before; mixing both directions is rejected with ParamErrors.
+// A first or last of 0 gives a PageSize of 0, an empty page.
+// Only DefaultPageSize and MaxPageSize of the options are used.
+func (a ConnectionArgs) Params(opts ...Options) (Params, error) {
+	var o Options
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	o = o.withDefaults()
+
+	p := Params{Page: 1, PageSize: o.DefaultPageSize}
+	errs := ParamErrors{}
+
+	forward := a.First != nil || a.After != nil
+	backward := a.Last != nil || a.Before != nil
+	if forward && backward {
+		errs["last"] = "last and before cannot be combined with first and after"
+		return p, errs
+	}
+
+	size, name := a.First, "first"
+	if backward {
+		size, name = a.Last, "last"
+		p.Direction = Backward
+		if a.Before != nil {
+			p.Cursor = *a.Before
+		}
+	} else if a.After != nil {
+		p.Cursor = *a.After
+	}
+
+	if size != nil {
+		switch {
+		case *size < 0:
+			errs[name] = fmt.Sprintf("%s must be a non-negative integer", name)
+		case o.MaxPageSize > 0 && *size > o.MaxPageSize:
+			errs[name] = fmt.Sprintf("%s must be at most %d", name, o.MaxPageSize)
+		default:
+			p.PageSize = *size
+		}
+	}
+
+	if len(errs) > 0 {
+		return p, errs
+	}
+	return p, nil
+}
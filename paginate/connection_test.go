@@ -0,0 +1,141 @@
+package paginate
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestConnectionFromRows(t *testing.T) {
+	cursorOf := func(id int) (string, error) { return "c" + strconv.Itoa(id), nil }
+
+	conn, err := ConnectionFromRows([]int{6, 5, 4, 3}, 3, Backward, "c7", cursorOf)
+	if err != nil {
+		t.Fatalf("ConnectionFromRows() failed: %v", err)
+	}
+
+	if got := conn.Nodes(); !slices.Equal(got, []int{4, 5, 6}) {
+		t.Errorf("Nodes() = %v, want [4 5 6]", got)
+	}
+	for _, e := range conn.Edges {
+		if want, _ := cursorOf(e.Node); e.Cursor != want {
+			t.Errorf("edge cursor = %q, want %q", e.Cursor, want)
+		}
+	}
+
+	data, err := json.Marshal(conn)
+	if err != nil {
+		t.Fatalf("Marshal() failed: %v", err)
+	}
+	want := `{"edges":[{"cursor":"c4","node":4},{"cursor":"c5","node":5},{"cursor":"c6","node":6}],` +
+		`"pageInfo":{"hasNextPage":true,"hasPreviousPage":true,"startCursor":"c4","endCursor":"c6"}}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestConnectionFromRowsZeroLimit(t *testing.T) {
+	cursorOf := func(id int) (string, error) { return "c" + strconv.Itoa(id), nil }
+
+	conn, err := ConnectionFromRows([]int{1, 2}, 0, Forward, "", cursorOf)
+	if err != nil {
+		t.Fatalf("ConnectionFromRows() failed: %v", err)
+	}
+	if len(conn.Edges) != 0 || conn.PageInfo.HasNextPage || conn.PageInfo.StartCursor != nil {
+		t.Errorf("ConnectionFromRows() = %+v, want no edges", conn)
+	}
+}
+
+func TestNewConnectionEmpty(t *testing.T) {
+	conn, err := NewConnection([]int{}, &CursorPagination{}, func(int) (string, error) { return "", nil })
+	if err != nil {
+		t.Fatalf("NewConnection() failed: %v", err)
+	}
+
+	data, _ := json.Marshal(conn)
+	want := `{"edges":[],"pageInfo":{"hasNextPage":false,"hasPreviousPage":false,"startCursor":null,"endCursor":null}}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	errEncode := errors.New("encode failed")
+	if _, err := NewConnection([]int{1}, &CursorPagination{}, func(int) (string, error) { return "", errEncode }); !errors.Is(err, errEncode) {
+		t.Errorf("NewConnection() error = %v, want %v", err, errEncode)
+	}
+}
+
+func TestConnectionArgsParams(t *testing.T) {
+	intp := func(n int) *int { return &n }
+	strp := func(s string) *string { return &s }
+
+	tests := []struct {
+		name       string
+		args       ConnectionArgs
+		want       Params
+		wantErrors ParamErrors
+	}{
+		{
+			name: "defaults",
+			args: ConnectionArgs{},
+			want: Params{Page: 1, PageSize: DefaultPageSize},
+		},
+		{
+			name: "first after",
+			args: ConnectionArgs{First: intp(5), After: strp("c3")},
+			want: Params{Page: 1, PageSize: 5, Cursor: "c3"},
+		},
+		{
+			name: "last before",
+			args: ConnectionArgs{Last: intp(5), Before: strp("c9")},
+			want: Params{Page: 1, PageSize: 5, Cursor: "c9", Direction: Backward},
+		},
+		{
+			name:       "mixed directions",
+			args:       ConnectionArgs{First: intp(5), Before: strp("c9")},
+			wantErrors: ParamErrors{"last": "last and before cannot be combined with first and after"},
+		},
+		{
+			name:       "negative first",
+			args:       ConnectionArgs{First: intp(-1)},
+			wantErrors: ParamErrors{"first": "first must be a non-negative integer"},
+		},
+		{
+			name: "zero first",
+			args: ConnectionArgs{First: intp(0)},
+			want: Params{Page: 1, PageSize: 0},
+		},
+		{
+			name:       "last above max",
+			args:       ConnectionArgs{Last: intp(MaxPageSize + 1)},
+			wantErrors: ParamErrors{"last": "last must be at most 100"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.args.Params()
+
+			if tt.wantErrors != nil {
+				var perr ParamErrors
+				if !errors.As(err, &perr) {
+					t.Fatalf("Params() error = %v, want ParamErrors", err)
+				}
+				for k, v := range tt.wantErrors {
+					if perr[k] != v {
+						t.Errorf("Params() errors[%q] = %q, want %q", k, perr[k], v)
+					}
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Params() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Params() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
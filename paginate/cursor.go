@@ -1,7 +1,10 @@
 package paginate
 
+import "slices"
+
 // CursorPagination represents cursor-based pagination metadata for "load more" pattern.
 // It stores the current cursor, next cursor, and whether more items are available.
+// Use a CursorCodec to produce opaque cursors instead of exposing raw IDs.
 type CursorPagination struct {
 	// Cursor is the cursor pointing to the current position in the dataset.
 	Cursor string `json:"cursor,omitempty"`
@@ -12,19 +15,92 @@ type CursorPagination struct {
 
 	// HasMore indicates whether there are more items available after the current batch.
 	HasMore bool `json:"has_more"`
+
+	// PrevCursor is the cursor for fetching the batch before the current one.
+	// Empty string indicates no previous items, or, with NewCursorPagination, that paging back is not supported.
+	PrevCursor string `json:"prev_cursor,omitempty"`
+
+	// HasPrevious indicates whether there are items before the current batch.
+	HasPrevious bool `json:"has_previous"`
 }
 
 // NewCursorPagination creates a new CursorPagination instance.
 // hasMore indicates whether there are more items available.
+// HasPrevious is set when cursor is not empty, as items were loaded before it.
 func NewCursorPagination(cursor string, nextCursor string, hasMore bool) *CursorPagination {
 	return &CursorPagination{
-		Cursor:     cursor,
-		NextCursor: nextCursor,
-		HasMore:    hasMore,
+		Cursor:      cursor,
+		NextCursor:  nextCursor,
+		HasMore:     hasMore,
+		HasPrevious: cursor != "",
 	}
 }
 
+// IsFirstPage checks if this is the first page (no previous items available).
+func (cp *CursorPagination) IsFirstPage() bool {
+	return !cp.HasPrevious
+}
+
 // IsLastPage checks if this is the last page (no more items available).
 func (cp *CursorPagination) IsLastPage() bool {
 	return !cp.HasMore
 }
+
+// Direction is the paging direction relative to a cursor.
+type Direction int
+
+const (
+	// Forward pages to the items after the cursor.
+	Forward Direction = iota
+
+	// Backward pages to the items before the cursor.
+	Backward
+)
+
+// CursorPage trims and orders rows fetched for a cursor page and computes its metadata.
+//
+// Rows must be fetched with limit+1 so the extra row tells whether more items exist.
+// Paging Forward, rows are fetched in sort order after the cursor; paging Backward,
+// they are fetched in reverse sort order before the cursor and a reversed copy is returned.
+// cursorOf encodes the cursor of an item, e.g. with a CursorCodec.
+// HasMore and HasPrevious are only set together with NextCursor and PrevCursor,
+// so an empty page, e.g. after the last item was deleted, reports neither.
+func CursorPage[T any](rows []T, limit int, direction Direction, cursor string, cursorOf func(T) (string, error)) ([]T, *CursorPagination, error) {
+	if limit < 1 {
+		limit = DefaultPageSize
+	}
+
+	hasExtra := len(rows) > limit
+	if hasExtra {
+		rows = rows[:limit]
+	}
+
+	cp := &CursorPagination{Cursor: cursor}
+	if direction == Backward {
+		rows = slices.Clone(rows)
+		slices.Reverse(rows)
+		cp.HasPrevious = hasExtra
+		cp.HasMore = cursor != ""
+	} else {
+		cp.HasMore = hasExtra
+		cp.HasPrevious = cursor != ""
+	}
+
+	if len(rows) == 0 {
+		cp.HasMore, cp.HasPrevious = false, false
+		return rows, cp, nil
+	}
+
+	var err error
+	if cp.HasMore {
+		if cp.NextCursor, err = cursorOf(rows[len(rows)-1]); err != nil {
+			return nil, nil, err
+		}
+	}
+	if cp.HasPrevious {
+		if cp.PrevCursor, err = cursorOf(rows[0]); err != nil {
+			return nil, nil, err
+		}
+	}
+	return rows, cp, nil
+}
@@ -1,30 +1,36 @@
 package paginate
 
 import (
+	"errors"
+	"slices"
+	"strconv"
 	"testing"
 )
 
 func TestNewCursorPagination(t *testing.T) {
 	tests := []struct {
-		name        string
-		cursor      string
-		nextCursor  string
-		hasMore     bool
-		wantHasMore bool
+		name            string
+		cursor          string
+		nextCursor      string
+		hasMore         bool
+		wantHasMore     bool
+		wantHasPrevious bool
 	}{
 		{
-			name:        "has more items",
-			cursor:      "cursor1",
-			nextCursor:  "cursor2",
-			hasMore:     true,
-			wantHasMore: true,
+			name:            "has more items",
+			cursor:          "cursor1",
+			nextCursor:      "cursor2",
+			hasMore:         true,
+			wantHasMore:     true,
+			wantHasPrevious: true,
 		},
 		{
-			name:        "no more items",
-			cursor:      "cursor1",
-			nextCursor:  "",
-			hasMore:     false,
-			wantHasMore: false,
+			name:            "no more items",
+			cursor:          "cursor1",
+			nextCursor:      "",
+			hasMore:         false,
+			wantHasMore:     false,
+			wantHasPrevious: true,
 		},
 		{
 			name:        "empty cursor",
@@ -42,6 +48,9 @@ func TestNewCursorPagination(t *testing.T) {
 				t.Errorf("NewCursorPagination() = {Cursor: %s, NextCursor: %s, HasMore: %v}, want {Cursor: %s, NextCursor: %s, HasMore: %v}",
 					got.Cursor, got.NextCursor, got.HasMore, tt.cursor, tt.nextCursor, tt.wantHasMore)
 			}
+			if got.HasPrevious != tt.wantHasPrevious {
+				t.Errorf("NewCursorPagination() HasPrevious = %v, want %v", got.HasPrevious, tt.wantHasPrevious)
+			}
 		})
 	}
 }
@@ -78,3 +87,131 @@ func TestIsLastPage(t *testing.T) {
 	}
 }
 
+func TestCursorPage(t *testing.T) {
+	cursorOf := func(id int) (string, error) { return "c" + strconv.Itoa(id), nil }
+
+	tests := []struct {
+		name            string
+		rows            []int
+		limit           int
+		direction       Direction
+		cursor          string
+		want            []int
+		wantNext        string
+		wantPrev        string
+		wantHasMore     bool
+		wantHasPrevious bool
+	}{
+		{
+			name:        "first page with more",
+			rows:        []int{1, 2, 3, 4},
+			limit:       3,
+			direction:   Forward,
+			want:        []int{1, 2, 3},
+			wantNext:    "c3",
+			wantHasMore: true,
+		},
+		{
+			name:      "single page",
+			rows:      []int{1, 2},
+			limit:     3,
+			direction: Forward,
+			want:      []int{1, 2},
+		},
+		{
+			name:            "forward after cursor, last page",
+			rows:            []int{4, 5},
+			limit:           3,
+			direction:       Forward,
+			cursor:          "c3",
+			want:            []int{4, 5},
+			wantPrev:        "c4",
+			wantHasPrevious: true,
+		},
+		{
+			name:            "backward before cursor with more",
+			rows:            []int{6, 5, 4, 3},
+			limit:           3,
+			direction:       Backward,
+			cursor:          "c7",
+			want:            []int{4, 5, 6},
+			wantNext:        "c6",
+			wantPrev:        "c4",
+			wantHasMore:     true,
+			wantHasPrevious: true,
+		},
+		{
+			name:        "backward reaching the start",
+			rows:        []int{2, 1},
+			limit:       3,
+			direction:   Backward,
+			cursor:      "c3",
+			want:        []int{1, 2},
+			wantNext:    "c2",
+			wantHasMore: true,
+		},
+		{
+			name:      "empty forward page after cursor",
+			rows:      []int{},
+			limit:     3,
+			direction: Forward,
+			cursor:    "c9",
+			want:      []int{},
+		},
+		{
+			name:      "empty backward page before cursor",
+			rows:      []int{},
+			limit:     3,
+			direction: Backward,
+			cursor:    "c1",
+			want:      []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, cp, err := CursorPage(tt.rows, tt.limit, tt.direction, tt.cursor, cursorOf)
+			if err != nil {
+				t.Fatalf("CursorPage() failed: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("CursorPage() items = %v, want %v", got, tt.want)
+			}
+			if cp.Cursor != tt.cursor || cp.NextCursor != tt.wantNext || cp.PrevCursor != tt.wantPrev {
+				t.Errorf("CursorPage() cursors = (%q, %q, %q), want (%q, %q, %q)",
+					cp.Cursor, cp.NextCursor, cp.PrevCursor, tt.cursor, tt.wantNext, tt.wantPrev)
+			}
+			if cp.HasMore != tt.wantHasMore || cp.HasPrevious != tt.wantHasPrevious {
+				t.Errorf("CursorPage() HasMore, HasPrevious = %v, %v, want %v, %v",
+					cp.HasMore, cp.HasPrevious, tt.wantHasMore, tt.wantHasPrevious)
+			}
+			if cp.IsFirstPage() == tt.wantHasPrevious {
+				t.Errorf("IsFirstPage() = %v, want %v", cp.IsFirstPage(), !tt.wantHasPrevious)
+			}
+		})
+	}
+}
+
+func TestCursorPageKeepsRows(t *testing.T) {
+	rows := []int{3, 2, 1}
+	cursorOf := func(id int) (string, error) { return "c" + strconv.Itoa(id), nil }
+
+	got, _, err := CursorPage(rows, 2, Backward, "c4", cursorOf)
+	if err != nil {
+		t.Fatalf("CursorPage() failed: %v", err)
+	}
+	if !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("CursorPage() items = %v, want [2 3]", got)
+	}
+	if !slices.Equal(rows, []int{3, 2, 1}) {
+		t.Errorf("rows = %v, want [3 2 1] unchanged", rows)
+	}
+}
+
+func TestCursorPageError(t *testing.T) {
+	errEncode := errors.New("encode failed")
+	_, _, err := CursorPage([]int{1, 2}, 1, Forward, "", func(int) (string, error) { return "", errEncode })
+	if !errors.Is(err, errEncode) {
+		t.Errorf("CursorPage() error = %v, want %v", err, errEncode)
+	}
+}
@@ -0,0 +1,222 @@
+package paginate
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// cursorVersion is the current cursor format version.
+const cursorVersion = 1
+
+// cursorHeaderSize is the size of version, expiry and scope hash.
+const cursorHeaderSize = 1 + 8 + 8
+
+// MinCursorSecretSize is the minimum size of cursor signing secrets in bytes.
+const MinCursorSecretSize = 32
+
+// Errors returned by CursorCodec.Decode.
+var (
+	ErrInvalidCursor  = errors.New("paginate: invalid cursor")
+	ErrCursorVersion  = errors.New("paginate: unsupported cursor version")
+	ErrCursorTampered = errors.New("paginate: cursor signature mismatch")
+	ErrCursorExpired  = errors.New("paginate: cursor expired")
+	ErrCursorScope    = errors.New("paginate: cursor issued for a different sort or filter")
+)
+
+// CursorCodec encodes keyset positions, the sort column values of the last item of a page,
+// into opaque signed cursors:
+//
+//	base64url(version (1 byte) | expiry (8 bytes) | scope hash (8 bytes) | keys (JSON) | HMAC-SHA256)
+//
+// A cursor is bound to a scope describing the sort and filters it was issued for,
+// e.g. "created_at desc,id desc;status=active", so it cannot be replayed against another query.
+type CursorCodec struct {
+	// TTL, when set, makes cursors expire after the duration.
+	TTL time.Duration
+
+	secrets [][]byte
+}
+
+// NewCursorCodec creates a codec that signs with secret.
+// Previous secrets are only used for decoding, so secrets can be rotated.
+func NewCursorCodec(secret []byte, previous ...[]byte) (*CursorCodec, error) {
+	c := &CursorCodec{}
+	for _, key := range append([][]byte{secret}, previous...) {
+		if len(key) < MinCursorSecretSize {
+			return nil, fmt.Errorf("paginate: cursor secret must be at least %d bytes long", MinCursorSecretSize)
+		}
+		c.secrets = append(c.secrets, append([]byte(nil), key...))
+	}
+	return c, nil
+}
+
+// Encode returns the cursor of the keys in the scope. Keys are the sort column values of the
+// last item, in sort order, e.g. its creation time and ID. Supported key types are strings,
+// integers, floats, bools, time.Time and nil.
+func (c *CursorCodec) Encode(scope string, keys ...any) (string, error) {
+	return c.encode(time.Now(), scope, keys)
+}
+
+// Decode verifies the cursor and returns its keys with the types they were encoded with;
+// all integers are returned as int64 or uint64 and floats as float64.
+// It returns ErrInvalidCursor, ErrCursorVersion, ErrCursorTampered, ErrCursorExpired or ErrCursorScope.
+func (c *CursorCodec) Decode(cursor, scope string) ([]any, error) {
+	return c.decode(time.Now(), cursor, scope)
+}
+
+// encode creates a cursor relative to now.
+func (c *CursorCodec) encode(now time.Time, scope string, keys []any) (string, error) {
+	encoded := make([][2]string, len(keys))
+	for i, k := range keys {
+		kind, value, err := encodeKey(k)
+		if err != nil {
+			return "", err
+		}
+		encoded[i] = [2]string{kind, value}
+	}
+	payload, err := json.Marshal(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	var expiry uint64
+	if c.TTL > 0 {
+		expiry = uint64(now.Add(c.TTL).Unix())
+	}
+
+	body := make([]byte, 0, cursorHeaderSize+len(payload)+sha256.Size)
+	body = append(body, cursorVersion)
+	body = binary.BigEndian.AppendUint64(body, expiry)
+	body = append(body, scopeHash(scope)...)
+	body = append(body, payload...)
+	body = append(body, cursorMAC(c.secrets[0], body)...)
+	return base64.RawURLEncoding.EncodeToString(body), nil
+}
+
+// decode verifies a cursor relative to now.
+func (c *CursorCodec) decode(now time.Time, cursor, scope string) ([]any, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(raw) < cursorHeaderSize+sha256.Size {
+		return nil, ErrInvalidCursor
+	}
+	if raw[0] != cursorVersion {
+		return nil, ErrCursorVersion
+	}
+
+	body, sig := raw[:len(raw)-sha256.Size], raw[len(raw)-sha256.Size:]
+	if !c.valid(body, sig) {
+		return nil, ErrCursorTampered
+	}
+
+	if expiry := binary.BigEndian.Uint64(body[1:9]); expiry != 0 {
+		if expiry > math.MaxInt64 || now.Unix() >= int64(expiry) {
+			return nil, ErrCursorExpired
+		}
+	}
+	if !hmac.Equal(body[9:cursorHeaderSize], scopeHash(scope)) {
+		return nil, ErrCursorScope
+	}
+
+	var encoded [][2]string
+	if err := json.Unmarshal(body[cursorHeaderSize:], &encoded); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	keys := make([]any, len(encoded))
+	for i, e := range encoded {
+		if keys[i], err = decodeKey(e[0], e[1]); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+	return keys, nil
+}
+
+// valid reports whether any secret produced the signature.
+func (c *CursorCodec) valid(body, sig []byte) bool {
+	for _, secret := range c.secrets {
+		if hmac.Equal(sig, cursorMAC(secret, body)) {
+			return true
+		}
+	}
+	return false
+}
+
+// cursorMAC returns the HMAC-SHA256 of the cursor body.
+func cursorMAC(secret, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// scopeHash returns the truncated SHA-256 of the scope, which identifies it without revealing it.
+func scopeHash(scope string) []byte {
+	sum := sha256.Sum256([]byte(scope))
+	return sum[:8]
+}
+
+// encodeKey returns the type tag and string form of a key.
+func encodeKey(k any) (string, string, error) {
+	switch v := k.(type) {
+	case nil:
+		return "n", "", nil
+	case string:
+		return "s", v, nil
+	case bool:
+		return "b", strconv.FormatBool(v), nil
+	case int:
+		return "i", strconv.FormatInt(int64(v), 10), nil
+	case int8:
+		return "i", strconv.FormatInt(int64(v), 10), nil
+	case int16:
+		return "i", strconv.FormatInt(int64(v), 10), nil
+	case int32:
+		return "i", strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return "i", strconv.FormatInt(v, 10), nil
+	case uint:
+		return "u", strconv.FormatUint(uint64(v), 10), nil
+	case uint8:
+		return "u", strconv.FormatUint(uint64(v), 10), nil
+	case uint16:
+		return "u", strconv.FormatUint(uint64(v), 10), nil
+	case uint32:
+		return "u", strconv.FormatUint(uint64(v), 10), nil
+	case uint64:
+		return "u", strconv.FormatUint(v, 10), nil
+	case float32:
+		return "f", strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case float64:
+		return "f", strconv.FormatFloat(v, 'g', -1, 64), nil
+	case time.Time:
+		return "t", v.Format(time.RFC3339Nano), nil
+	}
+	return "", "", fmt.Errorf("paginate: unsupported cursor key type %T", k)
+}
+
+// decodeKey parses a key from its type tag and string form.
+func decodeKey(kind, value string) (any, error) {
+	switch kind {
+	case "n":
+		return nil, nil
+	case "s":
+		return value, nil
+	case "b":
+		return strconv.ParseBool(value)
+	case "i":
+		return strconv.ParseInt(value, 10, 64)
+	case "u":
+		return strconv.ParseUint(value, 10, 64)
+	case "f":
+		return strconv.ParseFloat(value, 64)
+	case "t":
+		return time.Parse(time.RFC3339Nano, value)
+	}
+	return nil, fmt.Errorf("paginate: unknown cursor key type %q", kind)
+}
@@ -0,0 +1,135 @@
+package paginate
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testCursorSecret    = bytes.Repeat([]byte("c"), MinCursorSecretSize)
+	testOldCursorSecret = bytes.Repeat([]byte("o"), MinCursorSecretSize)
+)
+
+const testScope = "created_at desc,id desc;status=active"
+
+func newTestCodec(t *testing.T, secret []byte, previous ...[]byte) *CursorCodec {
+	t.Helper()
+	c, err := NewCursorCodec(secret, previous...)
+	if err != nil {
+		t.Fatalf("NewCursorCodec() failed: %v", err)
+	}
+	return c
+}
+
+func TestCursorCodecRoundTrip(t *testing.T) {
+	c := newTestCodec(t, testCursorSecret)
+	createdAt := time.Date(2025, 3, 1, 12, 30, 0, 123456789, time.UTC)
+
+	cursor, err := c.Encode(testScope, createdAt, int64(42), "a b", uint8(7), 1.5, true, nil)
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+	if strings.ContainsAny(cursor, "+/=") || strings.Contains(cursor, "42") {
+		t.Errorf("Encode() = %q, want opaque base64url cursor", cursor)
+	}
+
+	keys, err := c.Decode(cursor, testScope)
+	if err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+
+	want := []any{createdAt, int64(42), "a b", uint64(7), 1.5, true, nil}
+	if len(keys) != len(want) {
+		t.Fatalf("Decode() = %v, want %v", keys, want)
+	}
+	if ts, ok := keys[0].(time.Time); !ok || !ts.Equal(createdAt) {
+		t.Errorf("Decode()[0] = %v, want %v", keys[0], createdAt)
+	}
+	for i := 1; i < len(want); i++ {
+		if keys[i] != want[i] {
+			t.Errorf("Decode()[%d] = %#v, want %#v", i, keys[i], want[i])
+		}
+	}
+}
+
+func TestCursorCodecErrors(t *testing.T) {
+	c := newTestCodec(t, testCursorSecret)
+	c.TTL = time.Hour
+	now := time.Unix(1700000000, 0)
+
+	cursor, err := c.encode(now, testScope, []any{int64(42)})
+	if err != nil {
+		t.Fatalf("encode() failed: %v", err)
+	}
+
+	raw, _ := base64.RawURLEncoding.DecodeString(cursor)
+	tampered := bytes.Clone(raw)
+	tampered[cursorHeaderSize+2] ^= 1
+	version := bytes.Clone(raw)
+	version[0] = 9
+
+	tests := []struct {
+		name    string
+		codec   *CursorCodec
+		at      time.Time
+		cursor  string
+		scope   string
+		wantErr error
+	}{
+		{name: "valid", codec: c, at: now, cursor: cursor, scope: testScope},
+		{name: "expired", codec: c, at: now.Add(time.Hour), cursor: cursor, scope: testScope, wantErr: ErrCursorExpired},
+		{name: "other scope", codec: c, at: now, cursor: cursor, scope: "created_at asc,id asc", wantErr: ErrCursorScope},
+		{name: "tampered", codec: c, at: now, cursor: base64.RawURLEncoding.EncodeToString(tampered), scope: testScope, wantErr: ErrCursorTampered},
+		{name: "other secret", codec: newTestCodec(t, testOldCursorSecret), at: now, cursor: cursor, scope: testScope, wantErr: ErrCursorTampered},
+		{name: "version", codec: c, at: now, cursor: base64.RawURLEncoding.EncodeToString(version), scope: testScope, wantErr: ErrCursorVersion},
+		{name: "raw id", codec: c, at: now, cursor: "42", scope: testScope, wantErr: ErrInvalidCursor},
+		{name: "not base64", codec: c, at: now, cursor: "!!", scope: testScope, wantErr: ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.codec.decode(tt.at, tt.cursor, tt.scope); !errors.Is(err, tt.wantErr) {
+				t.Errorf("decode() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCursorCodecNoExpiry(t *testing.T) {
+	c := newTestCodec(t, testCursorSecret)
+	cursor, err := c.encode(time.Unix(0, 0), testScope, []any{"x"})
+	if err != nil {
+		t.Fatalf("encode() failed: %v", err)
+	}
+	if _, err := c.Decode(cursor, testScope); err != nil {
+		t.Errorf("Decode() error = %v, want cursor without TTL never to expire", err)
+	}
+}
+
+func TestCursorCodecRotation(t *testing.T) {
+	old := newTestCodec(t, testOldCursorSecret)
+	rotated := newTestCodec(t, testCursorSecret, testOldCursorSecret)
+
+	cursor, err := old.Encode(testScope, "x")
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+	if _, err := rotated.Decode(cursor, testScope); err != nil {
+		t.Errorf("Decode() error = %v, want cursor of previous secret accepted", err)
+	}
+}
+
+func TestCursorCodecInvalidInput(t *testing.T) {
+	if _, err := NewCursorCodec([]byte("short")); err == nil {
+		t.Error("NewCursorCodec() should return error for short secret")
+	}
+
+	c := newTestCodec(t, testCursorSecret)
+	if _, err := c.Encode(testScope, struct{}{}); err == nil {
+		t.Error("Encode() should return error for unsupported key type")
+	}
+}
@@ -0,0 +1,206 @@
+package paginate
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Options configures how pagination parameters are read.
+type Options struct {
+	// PageParam is the page number parameter. Empty means "page".
+	PageParam string
+
+	// PageSizeParam is the page size parameter. Empty means "page_size".
+	PageSizeParam string
+
+	// LimitParam is read as page size when PageSizeParam is absent,
+	// for cursor based endpoints. Empty means "limit".
+	LimitParam string
+
+	// CursorParam is the cursor parameter, paging forward. Empty means "cursor".
+	CursorParam string
+
+	// AfterParam is the cursor parameter paging forward. Empty means "after".
+	AfterParam string
+
+	// BeforeParam is the cursor parameter paging backward. Empty means "before".
+	BeforeParam string
+
+	// DefaultPageSize is used when no page size is given. Zero means DefaultPageSize.
+	DefaultPageSize int
+
+	// MaxPageSize is the largest accepted page size. Zero means MaxPageSize.
+	MaxPageSize int
+}
+
+// withDefaults fills in the zero fields.
+func (o Options) withDefaults() Options {
+	if o.PageParam == "" {
+		o.PageParam = "page"
+	}
+	if o.PageSizeParam == "" {
+		o.PageSizeParam = "page_size"
+	}
+	if o.LimitParam == "" {
+		o.LimitParam = "limit"
+	}
+	if o.CursorParam == "" {
+		o.CursorParam = "cursor"
+	}
+	if o.AfterParam == "" {
+		o.AfterParam = "after"
+	}
+	if o.BeforeParam == "" {
+		o.BeforeParam = "before"
+	}
+	if o.DefaultPageSize <= 0 {
+		o.DefaultPageSize = DefaultPageSize
+	}
+	if o.MaxPageSize <= 0 {
+		o.MaxPageSize = MaxPageSize
+	}
+	return o
+}
+
+// Params are the pagination parameters of a request.
+type Params struct {
+	// Page is the page number (1-indexed).
+	Page int
+
+	// PageSize is the number of items per page.
+	PageSize int
+
+	// Cursor is the opaque cursor, empty for the first page.
+	Cursor string
+
+	// Direction is Backward if the cursor was given as BeforeParam.
+	Direction Direction
+}
+
+// Offset returns the offset of the page.
+func (p Params) Offset() int {
+	return GetOffset(p.Page, p.PageSize)
+}
+
+// Limit returns the number of items to fetch.
+func (p Params) Limit() int {
+	return p.PageSize
+}
+
+// ParamErrors maps invalid parameter names to messages.
+type ParamErrors map[string]string
+
+// Error returns the messages sorted by parameter.
+func (e ParamErrors) Error() string {
+	keys := make([]string, 0, len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	messages := make([]string, len(keys))
+	for i, k := range keys {
+		messages[i] = e[k]
+	}
+	return "paginate: " + strings.Join(messages, "; ")
+}
+
+// Map returns the errors as payload for httputil.ValidationError.
+func (e ParamErrors) Map() map[string]any {
+	m := make(map[string]any, len(e))
+	for k, v := range e {
+		m[k] = v
+	}
+	return m
+}
+
+// FromValues parses pagination parameters from query values.
+// Missing parameters get their defaults; invalid ones are returned as ParamErrors.
+func FromValues(values url.Values, opts ...Options) (Params, error) {
+	var o Options
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	o = o.withDefaults()
+
+	p := Params{Page: 1, PageSize: o.DefaultPageSize}
+	errs := ParamErrors{}
+
+	after := values.Get(o.AfterParam)
+	if after == "" {
+		after = values.Get(o.CursorParam)
+	}
+	before := values.Get(o.BeforeParam)
+	switch {
+	case after != "" && before != "":
+		errs[o.BeforeParam] = fmt.Sprintf("%s cannot be combined with %s", o.BeforeParam, o.AfterParam)
+	case before != "":
+		p.Cursor, p.Direction = before, Backward
+	default:
+		p.Cursor = after
+	}
+
+	if v := values.Get(o.PageParam); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			errs[o.PageParam] = fmt.Sprintf("%s must be a positive integer", o.PageParam)
+		} else {
+			p.Page = n
+		}
+	}
+
+	sizeParam := o.PageSizeParam
+	if !values.Has(sizeParam) && values.Has(o.LimitParam) {
+		sizeParam = o.LimitParam
+	}
+	if v := values.Get(sizeParam); v != "" {
+		n, err := strconv.Atoi(v)
+		switch {
+		case err != nil || n < 1:
+			errs[sizeParam] = fmt.Sprintf("%s must be a positive integer", sizeParam)
+		case o.MaxPageSize > 0 && n > o.MaxPageSize:
+			errs[sizeParam] = fmt.Sprintf("%s must be at most %d", sizeParam, o.MaxPageSize)
+		default:
+			p.PageSize = n
+		}
+	}
+
+	if _, ok := errs[o.PageParam]; !ok && offsetOverflows(p.Page, p.PageSize) {
+		errs[o.PageParam] = fmt.Sprintf("%s is too large", o.PageParam)
+	}
+
+	if len(errs) > 0 {
+		return p, errs
+	}
+	return p, nil
+}
+
+// offsetOverflows reports whether the offset of the page does not fit in an int.
+func offsetOverflows(page, pageSize int) bool {
+	return page > 1 && pageSize > 0 && page-1 > math.MaxInt/pageSize
+}
+
+// FromRequest parses pagination parameters from the query of a net/http request.
+func FromRequest(r *http.Request, opts ...Options) (Params, error) {
+	return FromValues(r.URL.Query(), opts...)
+}
+
+// FromGin parses pagination parameters from the query of a Gin request.
+// On error, pass ParamErrors.Map to httputil.ValidationError:
+//
+//	params, err := paginate.FromGin(c)
+//	var perr paginate.ParamErrors
+//	if errors.As(err, &perr) {
+//		httputil.ValidationError(c, perr.Map(), "Invalid pagination")
+//		return
+//	}
+func FromGin(c *gin.Context, opts ...Options) (Params, error) {
+	return FromValues(c.Request.URL.Query(), opts...)
+}
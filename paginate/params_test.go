@@ -0,0 +1,148 @@
+package paginate
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestFromValues(t *testing.T) {
+	custom := Options{PageParam: "p", PageSizeParam: "per_page", DefaultPageSize: 25, MaxPageSize: 50}
+
+	tests := []struct {
+		name       string
+		query      string
+		opts       []Options
+		want       Params
+		wantErrors ParamErrors
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			want:  Params{Page: 1, PageSize: DefaultPageSize},
+		},
+		{
+			name:  "page and page size",
+			query: "page=3&page_size=20",
+			want:  Params{Page: 3, PageSize: 20},
+		},
+		{
+			name:  "cursor and limit",
+			query: "cursor=abc&limit=30",
+			want:  Params{Page: 1, PageSize: 30, Cursor: "abc"},
+		},
+		{
+			name:  "after",
+			query: "after=abc",
+			want:  Params{Page: 1, PageSize: DefaultPageSize, Cursor: "abc"},
+		},
+		{
+			name:  "before",
+			query: "before=abc",
+			want:  Params{Page: 1, PageSize: DefaultPageSize, Cursor: "abc", Direction: Backward},
+		},
+		{
+			name:       "before and after",
+			query:      "before=abc&after=def",
+			wantErrors: ParamErrors{"before": "before cannot be combined with after"},
+		},
+		{
+			name:  "page size wins over limit",
+			query: "page_size=15&limit=30",
+			want:  Params{Page: 1, PageSize: 15},
+		},
+		{
+			name:  "custom names and defaults",
+			query: "p=2&page=9",
+			opts:  []Options{custom},
+			want:  Params{Page: 2, PageSize: 25},
+		},
+		{
+			name:       "custom max",
+			query:      "per_page=60",
+			opts:       []Options{custom},
+			wantErrors: ParamErrors{"per_page": "per_page must be at most 50"},
+		},
+		{
+			name:       "page size above default max",
+			query:      "page_size=1000",
+			wantErrors: ParamErrors{"page_size": "page_size must be at most 100"},
+		},
+		{
+			name:       "page offset overflows",
+			query:      "page=9223372036854775807&page_size=20",
+			wantErrors: ParamErrors{"page": "page is too large"},
+		},
+		{
+			name:  "invalid values",
+			query: "page=0&limit=abc",
+			wantErrors: ParamErrors{
+				"page":  "page must be a positive integer",
+				"limit": "limit must be a positive integer",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			got, err := FromValues(values, tt.opts...)
+
+			if tt.wantErrors != nil {
+				var perr ParamErrors
+				if !errors.As(err, &perr) {
+					t.Fatalf("FromValues() error = %v, want ParamErrors", err)
+				}
+				if len(perr) != len(tt.wantErrors) {
+					t.Errorf("FromValues() errors = %v, want %v", perr, tt.wantErrors)
+				}
+				for k, v := range tt.wantErrors {
+					if perr[k] != v || perr.Map()[k] != v {
+						t.Errorf("FromValues() errors[%q] = %q, want %q", k, perr[k], v)
+					}
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("FromValues() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FromValues() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParamsOffsetLimit(t *testing.T) {
+	p := Params{Page: 3, PageSize: 20}
+	if p.Offset() != 40 || p.Limit() != 20 {
+		t.Errorf("Offset(), Limit() = %d, %d, want 40, 20", p.Offset(), p.Limit())
+	}
+}
+
+func TestParamErrorsError(t *testing.T) {
+	err := ParamErrors{"page_size": "page_size must be at most 100", "page": "page must be a positive integer"}
+	want := "paginate: page must be a positive integer; page_size must be at most 100"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestFromRequestAndGin(t *testing.T) {
+	req := httptest.NewRequest("GET", "/items?page=2&page_size=5", nil)
+	want := Params{Page: 2, PageSize: 5}
+
+	if got, err := FromRequest(req); err != nil || got != want {
+		t.Errorf("FromRequest() = (%+v, %v), want %+v", got, err, want)
+	}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = req
+	if got, err := FromGin(c); err != nil || got != want {
+		t.Errorf("FromGin() = (%+v, %v), want %+v", got, err, want)
+	}
+}
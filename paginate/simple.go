@@ -7,6 +7,9 @@ import (
 // DefaultPageSize is the default page size when not specified or invalid.
 const DefaultPageSize = 10
 
+// MaxPageSize is the largest page size GetLimit returns and FromValues accepts. Zero means no limit.
+var MaxPageSize = 100
+
 // SimplePagination represents simple offset-limit pagination metadata.
 type SimplePagination struct {
 	// Total is the total number of items available.
@@ -50,10 +53,14 @@ func GetOffset[T constraints.Integer](page T, pageSize T) T {
 	return (page - 1) * pageSize
 }
 
-// GetLimit returns the page size (limit), or DefaultPageSize if invalid.
+// GetLimit returns the page size (limit), or DefaultPageSize if invalid,
+// capped at MaxPageSize.
 func GetLimit[T constraints.Integer](pageSize T) T {
 	if pageSize <= 0 {
 		return T(DefaultPageSize)
 	}
+	if MaxPageSize > 0 && uint64(pageSize) > uint64(MaxPageSize) {
+		return T(MaxPageSize)
+	}
 	return pageSize
 }
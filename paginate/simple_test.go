@@ -191,6 +191,11 @@ func TestGetLimit(t *testing.T) {
 			pageSize: 50,
 			want:     50,
 		},
+		{
+			name:     "pageSize above MaxPageSize",
+			pageSize: 500,
+			want:     MaxPageSize,
+		},
 		{
 			name:     "pageSize 0",
 			pageSize: 0,
@@ -0,0 +1,310 @@
+package paginate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Dialect is the SQL dialect a Query is built for, which decides the placeholder style.
+type Dialect int
+
+const (
+	// MySQL uses ? placeholders.
+	MySQL Dialect = iota
+
+	// Postgres uses $1, $2, ... placeholders.
+	Postgres
+
+	// SQLite uses ? placeholders.
+	SQLite
+)
+
+// columnPattern matches the column names accepted in Sort, such as "created_at" or "p.id".
+var columnPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// Sort is a column of the ORDER BY clause.
+type Sort struct {
+	// Column is the column name, optionally qualified, e.g. "p.created_at".
+	Column string
+
+	// Desc sorts in descending order.
+	Desc bool
+}
+
+// Querier runs queries. It is implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Query builds paginated queries from a base SELECT.
+//
+//	q := paginate.Query{
+//		Dialect: paginate.Postgres,
+//		Select:  "SELECT id, title, created_at FROM posts",
+//		Where:   "author_id = ?",
+//		Args:    []any{authorID},
+//		OrderBy: []paginate.Sort{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}},
+//	}
+//
+// Write placeholders as ? in Where; they are rewritten for the dialect.
+// The sort columns must identify rows uniquely for keyset pagination, typically by ending with the primary key,
+// and must be NOT NULL: a NULL never compares less or greater, so KeysetSQL rejects nil keys.
+type Query struct {
+	// Dialect is the target SQL dialect.
+	Dialect Dialect
+
+	// Select is the query up to, and without, the WHERE clause.
+	Select string
+
+	// Where is the optional filter condition.
+	Where string
+
+	// Args are the arguments of the placeholders in Where.
+	Args []any
+
+	// OrderBy is the sort order.
+	OrderBy []Sort
+}
+
+// OffsetSQL returns the query of a page with LIMIT and OFFSET.
+// The page size is limited with GetLimit.
+func (q Query) OffsetSQL(page, pageSize int) (string, []any, error) {
+	if err := q.validate(); err != nil {
+		return "", nil, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	pageSize = GetLimit(pageSize)
+	if offsetOverflows(page, pageSize) {
+		return "", nil, fmt.Errorf("paginate: page %d is too large for page size %d", page, pageSize)
+	}
+
+	var b strings.Builder
+	args := q.where(&b, "")
+	q.orderBy(&b, false)
+	b.WriteString(" LIMIT ? OFFSET ?")
+	args = append(args, pageSize, GetOffset(page, pageSize))
+
+	return q.rebind(b.String()), args, nil
+}
+
+// KeysetSQL returns the query of a page after (Forward) or before (Backward) the row with
+// the keys, the OrderBy column values of a cursor's row, e.g. as decoded by CursorCodec.
+// Nil keys select the first page. The query fetches limit+1 rows; paging Backward it fetches
+// in reverse order. Pass the rows to CursorPage, which trims and orders them.
+func (q Query) KeysetSQL(keys []any, direction Direction, limit int) (string, []any, error) {
+	if err := q.validate(); err != nil {
+		return "", nil, err
+	}
+	if keys != nil && len(keys) != len(q.OrderBy) {
+		return "", nil, fmt.Errorf("paginate: got %d keyset values for %d sort columns", len(keys), len(q.OrderBy))
+	}
+	for i, key := range keys {
+		if key == nil {
+			return "", nil, fmt.Errorf("paginate: keyset value of sort column %q is nil", q.OrderBy[i].Column)
+		}
+	}
+	if limit < 1 {
+		limit = DefaultPageSize
+	}
+
+	reverse := direction == Backward
+
+	var b strings.Builder
+	args := q.where(&b, keysetCondition(q.OrderBy, reverse, keys))
+	if keys != nil {
+		args = append(args, keysetArgs(q.OrderBy, keys)...)
+	}
+	q.orderBy(&b, reverse)
+	b.WriteString(" LIMIT ?")
+	args = append(args, limit+1)
+
+	return q.rebind(b.String()), args, nil
+}
+
+// CountSQL returns the query counting all rows matching the filter.
+func (q Query) CountSQL() (string, []any) {
+	var b strings.Builder
+	b.WriteString("SELECT COUNT(*) FROM (")
+	args := q.where(&b, "")
+	b.WriteString(") AS paginate_count")
+	return q.rebind(b.String()), args
+}
+
+// Count runs CountSQL.
+func (q Query) Count(ctx context.Context, db Querier) (int64, error) {
+	query, args := q.CountSQL()
+
+	var total int64
+	if err := db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// QueryPage runs the count and page queries of an offset page.
+// The caller scans and closes the returned rows.
+func (q Query) QueryPage(ctx context.Context, db Querier, page, pageSize int) (*sql.Rows, *SimplePagination, error) {
+	total, err := q.Count(ctx, db)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query, args, err := q.OffsetSQL(page, pageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rows, NewSimplePagination(total, page, GetLimit(pageSize)), nil
+}
+
+// validate checks the parts of the query that are interpolated into SQL.
+func (q Query) validate() error {
+	if len(q.OrderBy) == 0 {
+		return errors.New("paginate: query has no sort columns")
+	}
+	for _, s := range q.OrderBy {
+		if !columnPattern.MatchString(s.Column) {
+			return fmt.Errorf("paginate: invalid sort column %q", s.Column)
+		}
+	}
+	return nil
+}
+
+// where writes the base query with the filter and an optional extra condition,
+// and returns the filter arguments.
+func (q Query) where(b *strings.Builder, extra string) []any {
+	b.WriteString(q.Select)
+
+	conditions := make([]string, 0, 2)
+	if q.Where != "" {
+		conditions = append(conditions, "("+q.Where+")")
+	}
+	if extra != "" {
+		conditions = append(conditions, extra)
+	}
+	if len(conditions) > 0 {
+		b.WriteString(" WHERE ")
+		b.WriteString(strings.Join(conditions, " AND "))
+	}
+	return append([]any(nil), q.Args...)
+}
+
+// orderBy writes the ORDER BY clause, optionally with every direction reversed.
+func (q Query) orderBy(b *strings.Builder, reverse bool) {
+	b.WriteString(" ORDER BY ")
+	for i, s := range q.OrderBy {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(s.Column)
+		if s.Desc != reverse {
+			b.WriteString(" DESC")
+		} else {
+			b.WriteString(" ASC")
+		}
+	}
+}
+
+// keysetCondition returns the condition selecting rows after the keys in the sort order.
+// Uniform sort directions use a row value comparison, e.g. (created_at, id) < (?, ?),
+// which databases can serve from a composite index; mixed directions expand to
+// a < ? OR (a = ? AND b > ?).
+func keysetCondition(sorts []Sort, reverse bool, keys []any) string {
+	if keys == nil {
+		return ""
+	}
+
+	op := func(s Sort) string {
+		if s.Desc != reverse {
+			return "<"
+		}
+		return ">"
+	}
+
+	uniform := true
+	for _, s := range sorts[1:] {
+		uniform = uniform && s.Desc == sorts[0].Desc
+	}
+
+	columns := make([]string, len(sorts))
+	for i, s := range sorts {
+		columns[i] = s.Column
+	}
+
+	if len(sorts) == 1 {
+		return columns[0] + " " + op(sorts[0]) + " ?"
+	}
+	if uniform {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(sorts)), ", ")
+		return "(" + strings.Join(columns, ", ") + ") " + op(sorts[0]) + " (" + placeholders + ")"
+	}
+
+	alternatives := make([]string, len(sorts))
+	for i, s := range sorts {
+		parts := make([]string, 0, i+1)
+		for _, prev := range columns[:i] {
+			parts = append(parts, prev+" = ?")
+		}
+		parts = append(parts, s.Column+" "+op(s)+" ?")
+		alternatives[i] = "(" + strings.Join(parts, " AND ") + ")"
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")"
+}
+
+// keysetArgs returns the arguments of keysetCondition in placeholder order.
+func keysetArgs(sorts []Sort, keys []any) []any {
+	uniform := true
+	for _, s := range sorts[1:] {
+		uniform = uniform && s.Desc == sorts[0].Desc
+	}
+	if len(sorts) == 1 || uniform {
+		return keys
+	}
+
+	args := make([]any, 0, len(keys)*(len(keys)+1)/2)
+	for i := range keys {
+		args = append(args, keys[:i+1]...)
+	}
+	return args
+}
+
+// rebind rewrites ? placeholders for the dialect, skipping quoted strings and identifiers.
+func (q Query) rebind(query string) string {
+	if q.Dialect != Postgres {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+
+	n := 0
+	var quote rune
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '?':
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
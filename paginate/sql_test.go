@@ -0,0 +1,278 @@
+package paginate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestQuerySQL(t *testing.T) {
+	q := Query{
+		Select:  "SELECT id, title FROM posts",
+		Where:   "author_id = ? AND title <> '?'",
+		Args:    []any{7},
+		OrderBy: []Sort{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}},
+	}
+	pg := q
+	pg.Dialect = Postgres
+	mixed := q
+	mixed.OrderBy = []Sort{{Column: "score", Desc: true}, {Column: "name"}, {Column: "id"}}
+	mixed.Where = ""
+	mixed.Args = nil
+
+	tests := []struct {
+		name     string
+		build    func() (string, []any, error)
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "offset",
+			build:    func() (string, []any, error) { return q.OffsetSQL(3, 20) },
+			wantSQL:  "SELECT id, title FROM posts WHERE (author_id = ? AND title <> '?') ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?",
+			wantArgs: []any{7, 20, 40},
+		},
+		{
+			name:     "offset postgres",
+			build:    func() (string, []any, error) { return pg.OffsetSQL(1, 500) },
+			wantSQL:  "SELECT id, title FROM posts WHERE (author_id = $1 AND title <> '?') ORDER BY created_at DESC, id DESC LIMIT $2 OFFSET $3",
+			wantArgs: []any{7, MaxPageSize, 0},
+		},
+		{
+			name:     "keyset first page",
+			build:    func() (string, []any, error) { return q.KeysetSQL(nil, Forward, 10) },
+			wantSQL:  "SELECT id, title FROM posts WHERE (author_id = ? AND title <> '?') ORDER BY created_at DESC, id DESC LIMIT ?",
+			wantArgs: []any{7, 11},
+		},
+		{
+			name:     "keyset forward postgres",
+			build:    func() (string, []any, error) { return pg.KeysetSQL([]any{"2025-01-01", 42}, Forward, 10) },
+			wantSQL:  "SELECT id, title FROM posts WHERE (author_id = $1 AND title <> '?') AND (created_at, id) < ($2, $3) ORDER BY created_at DESC, id DESC LIMIT $4",
+			wantArgs: []any{7, "2025-01-01", 42, 11},
+		},
+		{
+			name:     "keyset backward",
+			build:    func() (string, []any, error) { return q.KeysetSQL([]any{"2025-01-01", 42}, Backward, 10) },
+			wantSQL:  "SELECT id, title FROM posts WHERE (author_id = ? AND title <> '?') AND (created_at, id) > (?, ?) ORDER BY created_at ASC, id ASC LIMIT ?",
+			wantArgs: []any{7, "2025-01-01", 42, 11},
+		},
+		{
+			name:     "keyset mixed directions",
+			build:    func() (string, []any, error) { return mixed.KeysetSQL([]any{9, "b", 3}, Forward, 5) },
+			wantSQL:  "SELECT id, title FROM posts WHERE ((score < ?) OR (score = ? AND name > ?) OR (score = ? AND name = ? AND id > ?)) ORDER BY score DESC, name ASC, id ASC LIMIT ?",
+			wantArgs: []any{9, 9, "b", 9, "b", 3, 6},
+		},
+		{
+			name: "count postgres",
+			build: func() (string, []any, error) {
+				query, args := pg.CountSQL()
+				return query, args, nil
+			},
+			wantSQL:  "SELECT COUNT(*) FROM (SELECT id, title FROM posts WHERE (author_id = $1 AND title <> '?')) AS paginate_count",
+			wantArgs: []any{7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.build()
+			if err != nil {
+				t.Fatalf("build failed: %v", err)
+			}
+			if query != tt.wantSQL {
+				t.Errorf("query =\n%s\nwant\n%s", query, tt.wantSQL)
+			}
+			if fmt.Sprint(args) != fmt.Sprint(tt.wantArgs) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestQuerySQLErrors(t *testing.T) {
+	q := Query{Select: "SELECT id FROM posts", OrderBy: []Sort{{Column: "id"}}}
+	if _, _, err := q.OffsetSQL(math.MaxInt, 20); err == nil {
+		t.Error("OffsetSQL() should return error for overflowing offset")
+	}
+
+	tests := []struct {
+		name  string
+		query Query
+		keys  []any
+	}{
+		{name: "no sort", query: Query{Select: "SELECT id FROM posts"}},
+		{name: "injected column", query: Query{Select: "SELECT id FROM posts", OrderBy: []Sort{{Column: "id; DROP TABLE posts"}}}},
+		{name: "key count", query: Query{Select: "SELECT id FROM posts", OrderBy: []Sort{{Column: "id"}}}, keys: []any{1, 2}},
+		{name: "nil key", query: Query{Select: "SELECT id FROM posts", OrderBy: []Sort{{Column: "deleted_at"}, {Column: "id"}}}, keys: []any{nil, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := tt.query.KeysetSQL(tt.keys, Forward, 10); err == nil {
+				t.Error("KeysetSQL() should return error")
+			}
+		})
+	}
+}
+
+type testPost struct {
+	ID        int64
+	CreatedAt string
+}
+
+// openTestDB returns an in-memory SQLite database with 25 posts sharing 5 creation days.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open() failed: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	if _, err := db.Exec(`CREATE TABLE posts (id INTEGER PRIMARY KEY, author_id INTEGER, created_at TEXT)`); err != nil {
+		t.Fatalf("create table failed: %v", err)
+	}
+	for i := 1; i <= 25; i++ {
+		day := fmt.Sprintf("2025-01-%02d", (i-1)%5+1)
+		if _, err := db.Exec(`INSERT INTO posts (id, author_id, created_at) VALUES (?, ?, ?)`, i, i%2, day); err != nil {
+			t.Fatalf("insert failed: %v", err)
+		}
+	}
+	return db
+}
+
+func scanPosts(t *testing.T, rows *sql.Rows) []testPost {
+	t.Helper()
+	defer rows.Close()
+
+	var posts []testPost
+	for rows.Next() {
+		var p testPost
+		if err := rows.Scan(&p.ID, &p.CreatedAt); err != nil {
+			t.Fatalf("Scan() failed: %v", err)
+		}
+		posts = append(posts, p)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("rows.Err() = %v", err)
+	}
+	return posts
+}
+
+func postIDs(posts []testPost) []int64 {
+	ids := make([]int64, len(posts))
+	for i, p := range posts {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func TestQueryPageSQLite(t *testing.T) {
+	db := openTestDB(t)
+	q := Query{
+		Dialect: SQLite,
+		Select:  "SELECT id, created_at FROM posts",
+		Where:   "author_id = ?",
+		Args:    []any{1},
+		OrderBy: []Sort{{Column: "id"}},
+	}
+
+	rows, sp, err := q.QueryPage(context.Background(), db, 2, 5)
+	if err != nil {
+		t.Fatalf("QueryPage() failed: %v", err)
+	}
+	if got := postIDs(scanPosts(t, rows)); !slices.Equal(got, []int64{11, 13, 15, 17, 19}) {
+		t.Errorf("QueryPage() ids = %v, want [11 13 15 17 19]", got)
+	}
+	if sp.Total != 13 || sp.Page != 2 || sp.PageSize != 5 || sp.LastPage != 3 {
+		t.Errorf("QueryPage() pagination = %+v, want total 13, page 2, size 5, last 3", sp)
+	}
+}
+
+func TestKeysetPaginationSQLite(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	codec := newTestCodec(t, testCursorSecret)
+
+	q := Query{
+		Dialect: SQLite,
+		Select:  "SELECT id, created_at FROM posts",
+		OrderBy: []Sort{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}},
+	}
+	const scope = "created_at desc,id desc"
+	cursorOf := func(p testPost) (string, error) { return codec.Encode(scope, p.CreatedAt, p.ID) }
+
+	fetch := func(cursor string, direction Direction) ([]testPost, *CursorPagination) {
+		t.Helper()
+		var keys []any
+		if cursor != "" {
+			var err error
+			if keys, err = codec.Decode(cursor, scope); err != nil {
+				t.Fatalf("Decode() failed: %v", err)
+			}
+		}
+
+		query, args, err := q.KeysetSQL(keys, direction, 10)
+		if err != nil {
+			t.Fatalf("KeysetSQL() failed: %v", err)
+		}
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			t.Fatalf("QueryContext() failed: %v", err)
+		}
+
+		items, cp, err := CursorPage(scanPosts(t, rows), 10, direction, cursor, cursorOf)
+		if err != nil {
+			t.Fatalf("CursorPage() failed: %v", err)
+		}
+		return items, cp
+	}
+
+	// Walk forward through all pages; ties on created_at are broken by id.
+	var all []int64
+	var pages [][]int64
+	var cp *CursorPagination
+	var items []testPost
+	cursor := ""
+	for {
+		items, cp = fetch(cursor, Forward)
+		pages = append(pages, postIDs(items))
+		all = append(all, postIDs(items)...)
+		if !cp.HasMore {
+			break
+		}
+		cursor = cp.NextCursor
+	}
+
+	want := []int64{25, 20, 15, 10, 5, 24, 19, 14, 9, 4, 23, 18, 13, 8, 3, 22, 17, 12, 7, 2, 21, 16, 11, 6, 1}
+	if !slices.Equal(all, want) {
+		t.Fatalf("forward ids = %v, want %v", all, want)
+	}
+	if len(pages) != 3 || !cp.HasPrevious {
+		t.Fatalf("got %d pages, HasPrevious %v, want 3 pages ending with HasPrevious", len(pages), cp.HasPrevious)
+	}
+
+	// Page backward from the last page to the previous ones.
+	items, cp = fetch(cp.PrevCursor, Backward)
+	if got := postIDs(items); !slices.Equal(got, pages[1]) {
+		t.Errorf("backward page = %v, want %v", got, pages[1])
+	}
+	if !cp.HasPrevious || !cp.HasMore {
+		t.Errorf("backward page HasPrevious, HasMore = %v, %v, want true, true", cp.HasPrevious, cp.HasMore)
+	}
+
+	items, cp = fetch(cp.PrevCursor, Backward)
+	if got := postIDs(items); !slices.Equal(got, pages[0]) {
+		t.Errorf("first page = %v, want %v", got, pages[0])
+	}
+	if cp.HasPrevious {
+		t.Error("first page HasPrevious = true, want false")
+	}
+}
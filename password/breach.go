@@ -0,0 +1,197 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// bloomMagic identifies bloom filter files written by BloomFilter.WriteTo.
+const bloomMagic = "GBBF"
+
+// bloomVersion is the current version of the bloom filter file format.
+const bloomVersion = 1
+
+// ErrInvalidBloomFilter is returned when reading a file that is not a valid bloom filter.
+var ErrInvalidBloomFilter = errors.New("password: invalid bloom filter")
+
+// BreachChecker reports whether a password appears in a breach corpus.
+type BreachChecker interface {
+	// IsBreached reports whether the password is part of the dataset.
+	IsBreached(password string) (bool, error)
+}
+
+// BloomFilter is a compact, probabilistic set of breached password SHA-1 digests.
+// It never misses a breached password but reports a small share of other passwords as breached.
+type BloomFilter struct {
+	k    uint8
+	m    uint64
+	n    uint64
+	bits []byte
+}
+
+// NewBloomFilter creates an empty bloom filter sized for n passwords
+// with the given false positive rate, e.g. 0.001.
+func NewBloomFilter(n int, falsePositiveRate float64) (*BloomFilter, error) {
+	if n < 1 {
+		return nil, errors.New("password: bloom filter size must be greater than 0")
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		return nil, errors.New("password: bloom filter false positive rate must be between 0 and 1")
+	}
+
+	m := math.Ceil(-float64(n) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(n) * math.Ln2)
+
+	f := &BloomFilter{
+		k: uint8(min(max(k, 1), math.MaxUint8)),
+		m: uint64(m),
+	}
+	f.bits = make([]byte, (f.m+7)/8)
+	return f, nil
+}
+
+// Add adds a password to the filter.
+func (f *BloomFilter) Add(password string) {
+	f.AddSHA1(sha1.Sum([]byte(password)))
+}
+
+// AddSHA1 adds the SHA-1 digest of a password to the filter.
+func (f *BloomFilter) AddSHA1(sum [sha1.Size]byte) {
+	for _, i := range f.indexes(sum) {
+		f.bits[i/8] |= 1 << (i % 8)
+	}
+	f.n++
+}
+
+// ContainsSHA1 reports whether the SHA-1 digest of a password is probably in the filter.
+func (f *BloomFilter) ContainsSHA1(sum [sha1.Size]byte) bool {
+	for _, i := range f.indexes(sum) {
+		if f.bits[i/8]&(1<<(i%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsBreached reports whether the password is probably in the filter. It never returns an error.
+func (f *BloomFilter) IsBreached(password string) (bool, error) {
+	return f.ContainsSHA1(sha1.Sum([]byte(password))), nil
+}
+
+// Len returns the number of digests added to the filter.
+func (f *BloomFilter) Len() int {
+	return int(f.n)
+}
+
+// indexes derives the k bit positions of a digest by double hashing.
+func (f *BloomFilter) indexes(sum [sha1.Size]byte) []uint64 {
+	h1 := binary.BigEndian.Uint64(sum[0:8])
+	h2 := binary.BigEndian.Uint64(sum[8:16]) | 1
+
+	indexes := make([]uint64, f.k)
+	for i := range indexes {
+		indexes[i] = (h1 + uint64(i)*h2) % f.m
+	}
+	return indexes
+}
+
+// WriteTo writes the filter in its binary file format.
+func (f *BloomFilter) WriteTo(w io.Writer) (int64, error) {
+	header := make([]byte, 0, bloomHeaderSize)
+	header = append(header, bloomMagic...)
+	header = append(header, bloomVersion, f.k)
+	header = binary.BigEndian.AppendUint64(header, f.m)
+	header = binary.BigEndian.AppendUint64(header, f.n)
+
+	n, err := w.Write(header)
+	if err != nil {
+		return int64(n), err
+	}
+
+	m, err := w.Write(f.bits)
+	return int64(n + m), err
+}
+
+// MaxBloomFilterSize is the largest bit array, in bytes, ReadBloomFilter accepts.
+var MaxBloomFilterSize int64 = 4 << 30
+
+// bloomHeaderSize is the size of the bloom filter file header.
+const bloomHeaderSize = len(bloomMagic) + 18
+
+// ReadBloomFilter reads a filter written by BloomFilter.WriteTo.
+func ReadBloomFilter(r io.Reader) (*BloomFilter, error) {
+	return readBloomFilter(r, -1)
+}
+
+// LoadBloomFilter reads a bloom filter file.
+func LoadBloomFilter(path string) (*BloomFilter, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return readBloomFilter(bufio.NewReader(file), info.Size())
+}
+
+// readBloomFilter reads a filter, checking the header against size, the total size in bytes,
+// before allocating the bit array. A negative size is unknown.
+func readBloomFilter(r io.Reader, size int64) (*BloomFilter, error) {
+	header := make([]byte, bloomHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBloomFilter, err)
+	}
+
+	if string(header[:len(bloomMagic)]) != bloomMagic || header[4] != bloomVersion {
+		return nil, ErrInvalidBloomFilter
+	}
+
+	f := &BloomFilter{
+		k: header[5],
+		m: binary.BigEndian.Uint64(header[6:14]),
+		n: binary.BigEndian.Uint64(header[14:22]),
+	}
+	if f.k == 0 || f.m == 0 || f.m > uint64(MaxBloomFilterSize)*8 {
+		return nil, ErrInvalidBloomFilter
+	}
+
+	length := int64((f.m + 7) / 8)
+	if size >= 0 && size != int64(bloomHeaderSize)+length {
+		return nil, fmt.Errorf("%w: file size %d does not match header", ErrInvalidBloomFilter, size)
+	}
+
+	f.bits = make([]byte, length)
+	if _, err := io.ReadFull(r, f.bits); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBloomFilter, err)
+	}
+	return f, nil
+}
+
+// SaveBloomFilter writes the filter to a file.
+func SaveBloomFilter(path string, f *BloomFilter) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(file)
+	if _, err := f.WriteTo(w); err != nil {
+		file.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
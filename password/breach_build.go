@@ -0,0 +1,151 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ListFormat is the format of a breach list fed to the dataset builders.
+type ListFormat int
+
+const (
+	// ListPlain has one plaintext password per line.
+	ListPlain ListFormat = iota
+
+	// ListSHA1 has one hex SHA-1 digest per line, optionally followed by ":COUNT"
+	// as in the Have I Been Pwned downloads.
+	ListSHA1
+)
+
+// ParseListFormat parses "plain" or "sha1" into a ListFormat.
+func ParseListFormat(s string) (ListFormat, error) {
+	switch strings.ToLower(s) {
+	case "plain":
+		return ListPlain, nil
+	case "sha1":
+		return ListSHA1, nil
+	}
+	return 0, fmt.Errorf("password: unknown list format %q", s)
+}
+
+// ScanList reads a breach list and calls fn with the SHA-1 digest and count of every entry.
+// Empty lines are skipped; entries without a count have a count of 1.
+func ScanList(r io.Reader, format ListFormat, fn func(sum [sha1.Size]byte, count int) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if text == "" {
+			continue
+		}
+
+		var sum [sha1.Size]byte
+		count := 1
+
+		switch format {
+		case ListPlain:
+			sum = sha1.Sum([]byte(text))
+		case ListSHA1:
+			digest, c, hasCount := strings.Cut(strings.TrimSpace(text), ":")
+			raw, err := hex.DecodeString(digest)
+			if err != nil || len(raw) != sha1.Size {
+				return fmt.Errorf("password: line %d: invalid sha1 digest", line)
+			}
+			copy(sum[:], raw)
+
+			if hasCount {
+				n, err := strconv.Atoi(c)
+				if err != nil || n < 1 {
+					return fmt.Errorf("password: line %d: invalid count", line)
+				}
+				count = n
+			}
+		default:
+			return fmt.Errorf("password: unknown list format %d", format)
+		}
+
+		if err := fn(sum, count); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// BuildBloomFilter builds a bloom filter from a breach list with the given false positive rate.
+// All digests are held in memory to size the filter; for very large lists create the filter
+// with NewBloomFilter and feed it from ScanList instead.
+func BuildBloomFilter(r io.Reader, format ListFormat, falsePositiveRate float64) (*BloomFilter, error) {
+	var sums [][sha1.Size]byte
+	err := ScanList(r, format, func(sum [sha1.Size]byte, _ int) error {
+		sums = append(sums, sum)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := NewBloomFilter(max(len(sums), 1), falsePositiveRate)
+	if err != nil {
+		return nil, err
+	}
+	for _, sum := range sums {
+		f.AddSHA1(sum)
+	}
+	return f, nil
+}
+
+// BuildRangeDir writes Have I Been Pwned style range files for a breach list into dir,
+// creating it if needed. Counts of repeated entries are summed.
+func BuildRangeDir(r io.Reader, format ListFormat, dir string) error {
+	ranges := map[string]map[string]int{}
+	err := ScanList(r, format, func(sum [sha1.Size]byte, count int) error {
+		digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+		prefix, suffix := digest[:rangePrefixLength], digest[rangePrefixLength:]
+
+		if ranges[prefix] == nil {
+			ranges[prefix] = map[string]int{}
+		}
+		ranges[prefix][suffix] += count
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	for prefix, suffixes := range ranges {
+		if err := writeRangeFile(filepath.Join(dir, prefix), suffixes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRangeFile writes sorted SUFFIX:COUNT lines to a range file.
+func writeRangeFile(path string, suffixes map[string]int) error {
+	keys := make([]string, 0, len(suffixes))
+	for suffix := range suffixes {
+		keys = append(keys, suffix)
+	}
+	slices.Sort(keys)
+
+	var b strings.Builder
+	for _, suffix := range keys {
+		fmt.Fprintf(&b, "%s:%d\n", suffix, suffixes[suffix])
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
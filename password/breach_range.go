@@ -0,0 +1,80 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// rangePrefixLength is the number of hex characters of the SHA-1 digest used as file name,
+// as in the Have I Been Pwned range API.
+const rangePrefixLength = 5
+
+// RangeDir is a directory of Have I Been Pwned style range files.
+// Each file is named after the first 5 uppercase hex characters of the SHA-1 digest
+// and lists the remaining 35 characters of every breached digest as SUFFIX:COUNT lines.
+type RangeDir struct {
+	dir string
+}
+
+// NewRangeDir opens a directory of range files.
+func NewRangeDir(dir string) (*RangeDir, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("password: %s is not a directory", dir)
+	}
+	return &RangeDir{dir: dir}, nil
+}
+
+// IsBreached reports whether the password is listed in the range files.
+func (d *RangeDir) IsBreached(password string) (bool, error) {
+	count, err := d.Count(password)
+	return count > 0, err
+}
+
+// Count returns how often the password was seen in breaches, or 0 if it is not listed.
+func (d *RangeDir) Count(password string) (int, error) {
+	return d.CountSHA1(sha1.Sum([]byte(password)))
+}
+
+// CountSHA1 returns how often the SHA-1 digest was seen in breaches, or 0 if it is not listed.
+func (d *RangeDir) CountSHA1(sum [sha1.Size]byte) (int, error) {
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := digest[:rangePrefixLength], digest[rangePrefixLength:]
+
+	file, err := os.Open(filepath.Join(d.dir, prefix))
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		hash, count, _ := strings.Cut(line, ":")
+		if !strings.EqualFold(hash, suffix) {
+			continue
+		}
+
+		n := 1
+		if count != "" {
+			if _, err := fmt.Sscanf(count, "%d", &n); err != nil || n < 1 {
+				n = 1
+			}
+		}
+		return n, nil
+	}
+	return 0, scanner.Err()
+}
@@ -0,0 +1,201 @@
+package password
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testBreachList = "hunter2\ncorrect horse\n\nletmein!\n"
+
+func TestBloomFilter(t *testing.T) {
+	f, err := BuildBloomFilter(strings.NewReader(testBreachList), ListPlain, 0.001)
+	if err != nil {
+		t.Fatalf("BuildBloomFilter() failed: %v", err)
+	}
+
+	if f.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", f.Len())
+	}
+
+	for _, pw := range []string{"hunter2", "correct horse", "letmein!"} {
+		if ok, err := f.IsBreached(pw); err != nil || !ok {
+			t.Errorf("IsBreached(%q) = (%v, %v), want (true, nil)", pw, ok, err)
+		}
+	}
+	if ok, _ := f.IsBreached("kX9#mQ2$vL"); ok {
+		t.Error("IsBreached() should return false for unlisted password")
+	}
+}
+
+func TestBloomFilterFalsePositiveRate(t *testing.T) {
+	f, err := NewBloomFilter(1000, 0.01)
+	if err != nil {
+		t.Fatalf("NewBloomFilter() failed: %v", err)
+	}
+	for i := range 1000 {
+		f.Add("breached-" + string(rune('a'+i%26)) + hex.EncodeToString([]byte{byte(i), byte(i >> 8)}))
+	}
+
+	falsePositives := 0
+	for i := range 10000 {
+		if ok, _ := f.IsBreached("clean-" + hex.EncodeToString([]byte{byte(i), byte(i >> 8)})); ok {
+			falsePositives++
+		}
+	}
+
+	if falsePositives > 300 {
+		t.Errorf("false positives = %d of 10000, want about 100", falsePositives)
+	}
+}
+
+func TestBloomFilterRoundTrip(t *testing.T) {
+	f, err := BuildBloomFilter(strings.NewReader(testBreachList), ListPlain, 0.001)
+	if err != nil {
+		t.Fatalf("BuildBloomFilter() failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "breached.bloom")
+	if err := SaveBloomFilter(path, f); err != nil {
+		t.Fatalf("SaveBloomFilter() failed: %v", err)
+	}
+
+	loaded, err := LoadBloomFilter(path)
+	if err != nil {
+		t.Fatalf("LoadBloomFilter() failed: %v", err)
+	}
+
+	if loaded.Len() != f.Len() {
+		t.Errorf("Len() = %d, want %d", loaded.Len(), f.Len())
+	}
+	if ok, _ := loaded.IsBreached("hunter2"); !ok {
+		t.Error("loaded filter should contain hunter2")
+	}
+}
+
+func TestReadBloomFilterInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{name: "empty", input: nil},
+		{name: "wrong magic", input: []byte("XXXX\x01\x07\x00\x00\x00\x00\x00\x00\x00\x08\x00\x00\x00\x00\x00\x00\x00\x00\x00")},
+		{name: "truncated bits", input: []byte("GBBF\x01\x07\x00\x00\x00\x00\x00\x00\x00\x80\x00\x00\x00\x00\x00\x00\x00\x00")},
+		{name: "truncated header", input: []byte("GBBF\x01\x07\x00\x00\x00")},
+		{name: "zero hashes", input: []byte("GBBF\x01\x00\x00\x00\x00\x00\x00\x00\x00\x08\x00\x00\x00\x00\x00\x00\x00\x00\x00")},
+		{name: "overflowing size", input: []byte("GBBF\x01\x07\xff\xff\xff\xff\xff\xff\xff\xff\x00\x00\x00\x00\x00\x00\x00\x00")},
+		{name: "oversized", input: []byte("GBBF\x01\x07\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadBloomFilter(bytes.NewReader(tt.input)); !errors.Is(err, ErrInvalidBloomFilter) {
+				t.Errorf("ReadBloomFilter() error = %v, want ErrInvalidBloomFilter", err)
+			}
+		})
+	}
+}
+
+func TestLoadBloomFilterSizeMismatch(t *testing.T) {
+	f, err := NewBloomFilter(100, 0.01)
+	if err != nil {
+		t.Fatalf("NewBloomFilter() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := f.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	for name, data := range map[string][]byte{
+		"truncated": buf.Bytes()[:buf.Len()-1],
+		"trailing":  append(bytes.Clone(buf.Bytes()), 0),
+	} {
+		path := filepath.Join(dir, name+".bloom")
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatalf("WriteFile() failed: %v", err)
+		}
+		if _, err := LoadBloomFilter(path); !errors.Is(err, ErrInvalidBloomFilter) {
+			t.Errorf("LoadBloomFilter(%s) error = %v, want ErrInvalidBloomFilter", name, err)
+		}
+	}
+}
+
+func TestRangeDir(t *testing.T) {
+	sum := sha1.Sum([]byte("hunter2"))
+	list := strings.ToUpper(hex.EncodeToString(sum[:])) + ":17\n" + testBreachList
+
+	dir := filepath.Join(t.TempDir(), "ranges")
+	if err := BuildRangeDir(strings.NewReader(list), ListSHA1, dir); err == nil {
+		t.Fatal("BuildRangeDir() should reject plaintext lines in sha1 format")
+	}
+
+	var b strings.Builder
+	for _, pw := range []string{"hunter2", "correct horse"} {
+		sum := sha1.Sum([]byte(pw))
+		b.WriteString(hex.EncodeToString(sum[:]) + ":5\n")
+	}
+	b.WriteString(strings.ToUpper(hex.EncodeToString(sum[:])) + ":12\n")
+
+	if err := BuildRangeDir(strings.NewReader(b.String()), ListSHA1, dir); err != nil {
+		t.Fatalf("BuildRangeDir() failed: %v", err)
+	}
+
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+	if _, err := os.Stat(filepath.Join(dir, digest[:5])); err != nil {
+		t.Fatalf("range file missing: %v", err)
+	}
+
+	d, err := NewRangeDir(dir)
+	if err != nil {
+		t.Fatalf("NewRangeDir() failed: %v", err)
+	}
+
+	if n, err := d.Count("hunter2"); err != nil || n != 17 {
+		t.Errorf("Count(hunter2) = (%d, %v), want (17, nil)", n, err)
+	}
+	if ok, err := d.IsBreached("correct horse"); err != nil || !ok {
+		t.Errorf("IsBreached(correct horse) = (%v, %v), want (true, nil)", ok, err)
+	}
+	if ok, err := d.IsBreached("kX9#mQ2$vL"); err != nil || ok {
+		t.Errorf("IsBreached() = (%v, %v), want (false, nil) for unlisted password", ok, err)
+	}
+}
+
+func TestNewRangeDirMissing(t *testing.T) {
+	if _, err := NewRangeDir(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("NewRangeDir() should return error for missing directory")
+	}
+}
+
+func TestParseListFormat(t *testing.T) {
+	if f, err := ParseListFormat("SHA1"); err != nil || f != ListSHA1 {
+		t.Errorf("ParseListFormat(SHA1) = (%v, %v), want ListSHA1", f, err)
+	}
+	if _, err := ParseListFormat("md5"); err == nil {
+		t.Error("ParseListFormat() should return error for unknown format")
+	}
+}
+
+func TestPolicyBreached(t *testing.T) {
+	f, err := BuildBloomFilter(strings.NewReader("violet-harbor-quantum\n"), ListPlain, 0.001)
+	if err != nil {
+		t.Fatalf("BuildBloomFilter() failed: %v", err)
+	}
+
+	p := DefaultPolicy
+	p.Breached = f
+
+	if got := rules(p.Validate("violet-harbor-quantum")); len(got) != 1 || got[0] != RuleBreached {
+		t.Errorf("Validate() rules = %v, want [breached]", got)
+	}
+	if got := p.Validate("violet-harbor-lantern"); len(got) != 0 {
+		t.Errorf("Validate() = %v, want no violations", got)
+	}
+}
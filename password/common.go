@@ -0,0 +1,32 @@
+package password
+
+import (
+	_ "embed"
+	"strings"
+)
+
+//go:embed common.txt
+var commonList string
+
+// commonRanks maps common passwords to their popularity rank, starting at 1.
+var commonRanks = func() map[string]int {
+	ranks := map[string]int{}
+	for i, word := range strings.Fields(commonList) {
+		word = strings.ToLower(word)
+		if _, ok := ranks[word]; !ok {
+			ranks[word] = i + 1
+		}
+	}
+	return ranks
+}()
+
+// IsCommon checks if the password is on the embedded list of common passwords.
+// The comparison ignores case and common leet substitutions such as "p@ssw0rd".
+func IsCommon(password string) bool {
+	lower := strings.ToLower(password)
+	if _, ok := commonRanks[lower]; ok {
+		return true
+	}
+	_, ok := commonRanks[unleet(lower)]
+	return ok
+}
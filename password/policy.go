@@ -0,0 +1,210 @@
+// Package password provides password policy validation, strength estimation and offline
+// breached password checks, to judge user-chosen passwords before they are hashed.
+package password
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Rule identifiers reported in Violation.Rule.
+const (
+	RuleMinLength  = "min_length"
+	RuleMaxLength  = "max_length"
+	RuleLower      = "lower"
+	RuleUpper      = "upper"
+	RuleDigit      = "digit"
+	RuleSpecial    = "special"
+	RuleMaxRepeats = "max_repeats"
+	RuleDenied     = "denied"
+	RuleBreached   = "breached"
+	RuleMinScore   = "min_score"
+)
+
+// DefaultField is the field name Violations.Map uses when none is given.
+var DefaultField = "password"
+
+// Violation describes one rule a password breaks.
+type Violation struct {
+	// Rule is the identifier of the broken rule, e.g. RuleMinLength.
+	Rule string `json:"rule"`
+
+	// Message is a human-readable description of the problem.
+	Message string `json:"message"`
+}
+
+// Violations is the list of rules a password breaks. It is empty when the password is acceptable.
+type Violations []Violation
+
+// Error joins the messages of all violations.
+func (v Violations) Error() string {
+	return "password " + strings.Join(v.Messages(), "; ")
+}
+
+// Messages returns the messages of all violations.
+func (v Violations) Messages() []string {
+	messages := make([]string, len(v))
+	for i, violation := range v {
+		messages[i] = violation.Message
+	}
+	return messages
+}
+
+// Map returns the violations as a field to messages map, ready for httputil.ValidationError.
+// If no field is given, DefaultField is used.
+func (v Violations) Map(field ...string) map[string]any {
+	name := DefaultField
+	if len(field) > 0 {
+		name = field[0]
+	}
+	return map[string]any{name: v.Messages()}
+}
+
+// Policy describes the rules user-chosen passwords must follow.
+type Policy struct {
+	// MinLength is the minimum number of characters.
+	MinLength int
+
+	// MaxLength is the maximum number of characters. Zero means no limit.
+	MaxLength int
+
+	// RequireLower requires at least one lowercase letter.
+	RequireLower bool
+
+	// RequireUpper requires at least one uppercase letter.
+	RequireUpper bool
+
+	// RequireDigit requires at least one digit.
+	RequireDigit bool
+
+	// RequireSpecial requires at least one character that is neither a letter nor a digit.
+	RequireSpecial bool
+
+	// MaxRepeats is the maximum number of times a character may repeat in a row. Zero means no limit.
+	MaxRepeats int
+
+	// DenyCommon rejects passwords on the embedded list of common passwords.
+	DenyCommon bool
+
+	// DenyList holds additional passwords to reject, e.g. the product name. Matching ignores case.
+	DenyList []string
+
+	// MinScore is the minimum Estimate score from 0 to 4. Zero disables the check.
+	MinScore int
+
+	// Breached, when set, rejects passwords found in a breach dataset such as a BloomFilter.
+	// Lookup errors are ignored, so an unavailable dataset does not block users.
+	Breached BreachChecker
+}
+
+// DefaultPolicy is the policy used by Validate.
+var DefaultPolicy = Policy{
+	MinLength:  8,
+	MaxLength:  128,
+	MaxRepeats: 3,
+	DenyCommon: true,
+	MinScore:   2,
+}
+
+// Validate checks the password against DefaultPolicy.
+// userInputs are values the password should not be based on, such as the name or email.
+func Validate(password string, userInputs ...string) Violations {
+	return DefaultPolicy.Validate(password, userInputs...)
+}
+
+// Validate checks the password against the policy and returns the broken rules.
+// userInputs are values the password should not be based on, such as the name or email.
+func (p Policy) Validate(password string, userInputs ...string) Violations {
+	var v Violations
+	add := func(rule, format string, args ...any) {
+		v = append(v, Violation{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		add(RuleMinLength, "must be at least %d characters long", p.MinLength)
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		add(RuleMaxLength, "must be at most %d characters long", p.MaxLength)
+	}
+
+	var lower, upper, digit, special bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r):
+			special = true
+		}
+	}
+	if p.RequireLower && !lower {
+		add(RuleLower, "must contain a lowercase letter")
+	}
+	if p.RequireUpper && !upper {
+		add(RuleUpper, "must contain an uppercase letter")
+	}
+	if p.RequireDigit && !digit {
+		add(RuleDigit, "must contain a digit")
+	}
+	if p.RequireSpecial && !special {
+		add(RuleSpecial, "must contain a special character")
+	}
+
+	if p.MaxRepeats > 0 && longestRun(password) > p.MaxRepeats {
+		add(RuleMaxRepeats, "must not repeat a character more than %d times in a row", p.MaxRepeats)
+	}
+
+	if p.isDenied(password) {
+		add(RuleDenied, "is too common")
+	} else if p.isBreached(password) {
+		add(RuleBreached, "has appeared in a data breach")
+	} else if p.MinScore > 0 && password != "" && Estimate(password, userInputs...).Score < p.MinScore {
+		add(RuleMinScore, "is too easy to guess")
+	}
+
+	return v
+}
+
+// isDenied reports whether the password is common or on the policy's deny list.
+func (p Policy) isDenied(password string) bool {
+	if p.DenyCommon && IsCommon(password) {
+		return true
+	}
+	for _, denied := range p.DenyList {
+		if strings.EqualFold(password, denied) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBreached reports whether the password is in the policy's breach dataset.
+func (p Policy) isBreached(password string) bool {
+	if p.Breached == nil {
+		return false
+	}
+	breached, err := p.Breached.IsBreached(password)
+	return err == nil && breached
+}
+
+// longestRun returns the length of the longest run of the same character.
+func longestRun(s string) int {
+	longest, run := 0, 0
+	var prev rune
+	for i, r := range []rune(s) {
+		if i > 0 && r == prev {
+			run++
+		} else {
+			run = 1
+		}
+		prev = r
+		longest = max(longest, run)
+	}
+	return longest
+}
@@ -0,0 +1,105 @@
+package password
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func rules(v Violations) []string {
+	var r []string
+	for _, violation := range v {
+		r = append(r, violation.Rule)
+	}
+	return r
+}
+
+func TestPolicyValidate(t *testing.T) {
+	strict := Policy{
+		MinLength:      10,
+		MaxLength:      20,
+		RequireLower:   true,
+		RequireUpper:   true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+		MaxRepeats:     2,
+		DenyCommon:     true,
+		DenyList:       []string{"GoBase2024!x"},
+	}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		password string
+		want     []string
+	}{
+		{name: "valid", policy: strict, password: "kX9#mQ2$vL", want: nil},
+		{name: "too short", policy: strict, password: "kX9#mQ2$", want: []string{RuleMinLength}},
+		{name: "too long", policy: strict, password: "kX9#mQ2$vL" + strings.Repeat("ab", 6), want: []string{RuleMaxLength}},
+		{name: "missing classes", policy: strict, password: "abcdefghijk", want: []string{RuleUpper, RuleDigit, RuleSpecial}},
+		{name: "repeats", policy: strict, password: "kX9#mQQQ2$vL", want: []string{RuleMaxRepeats}},
+		{name: "common", policy: Policy{DenyCommon: true}, password: "Password1", want: []string{RuleDenied}},
+		{name: "deny list", policy: strict, password: "gobase2024!X", want: []string{RuleDenied}},
+		{name: "weak score", policy: Policy{MinScore: 3}, password: "jsmith2024", want: []string{RuleMinScore}},
+		{name: "user input", policy: Policy{MinScore: 2}, password: "johnsmith", want: []string{RuleMinScore}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.Validate(tt.password, "john.smith@example.com")
+			if !reflect.DeepEqual(rules(got), tt.want) {
+				t.Errorf("Validate() rules = %v, want %v", rules(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateDefaultPolicy(t *testing.T) {
+	if v := Validate("violet-harbor-quantum"); len(v) != 0 {
+		t.Errorf("Validate() = %v, want no violations", v)
+	}
+
+	if v := Validate("letmein"); !reflect.DeepEqual(rules(v), []string{RuleMinLength, RuleDenied}) {
+		t.Errorf("Validate() rules = %v, want [min_length denied]", rules(v))
+	}
+}
+
+func TestViolationsMap(t *testing.T) {
+	v := Violations{
+		{Rule: RuleMinLength, Message: "must be at least 8 characters long"},
+		{Rule: RuleDenied, Message: "is too common"},
+	}
+
+	want := map[string]any{"password": []string{"must be at least 8 characters long", "is too common"}}
+	if got := v.Map(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Map() = %v, want %v", got, want)
+	}
+
+	if got := v.Map("new_password"); got["new_password"] == nil {
+		t.Errorf("Map(\"new_password\") = %v, want new_password key", got)
+	}
+
+	if got := v.Error(); got != "password must be at least 8 characters long; is too common" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestIsCommon(t *testing.T) {
+	tests := []struct {
+		password string
+		want     bool
+	}{
+		{password: "123456", want: true},
+		{password: "PASSWORD", want: true},
+		{password: "p4ssw0rd", want: true},
+		{password: "kX9#mQ2$vL", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			if got := IsCommon(tt.password); got != tt.want {
+				t.Errorf("IsCommon(%q) = %v, want %v", tt.password, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,341 @@
+package password
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// maxEstimateLength is the number of runes Estimate looks at; longer passwords
+// only gain brute force entropy for the remaining runes.
+const maxEstimateLength = 256
+
+// Score thresholds in bits, equivalent to zxcvbn's 10^3, 10^6, 10^8 and 10^10 guesses.
+var scoreThresholds = [...]float64{
+	3 * math.Log2(10),
+	6 * math.Log2(10),
+	8 * math.Log2(10),
+	10 * math.Log2(10),
+}
+
+// keyboardRows are the character runs typed by walking a keyboard row.
+var keyboardRows = []string{
+	"1234567890",
+	"qwertyuiop",
+	"asdfghjkl",
+	"zxcvbnm",
+	"qazwsxedcrfvtgbyhnujmikolp",
+}
+
+// leetTable maps common character substitutions back to letters.
+var leetTable = map[rune]rune{
+	'4': 'a',
+	'@': 'a',
+	'8': 'b',
+	'3': 'e',
+	'1': 'i',
+	'!': 'i',
+	'0': 'o',
+	'$': 's',
+	'5': 's',
+	'7': 't',
+	'+': 't',
+}
+
+// Strength is an estimate of how hard a password is to guess.
+type Strength struct {
+	// Guesses is the estimated number of guesses needed to find the password.
+	Guesses float64 `json:"guesses"`
+
+	// Entropy is log2(Guesses), in bits.
+	Entropy float64 `json:"entropy"`
+
+	// Score ranges from 0 (too guessable) to 4 (very unguessable), like zxcvbn.
+	Score int `json:"score"`
+}
+
+// Estimate estimates the strength of the password in the style of zxcvbn.
+// The password is split into the cheapest sequence of guessable patterns: common passwords
+// (with case and leet variations), user inputs such as the name or email, repeats, sequences,
+// keyboard walks and years. Everything else is brute forced over the character classes used.
+func Estimate(password string, userInputs ...string) Strength {
+	runes := []rune(password)
+	tail := 0
+	if len(runes) > maxEstimateLength {
+		tail = len(runes) - maxEstimateLength
+		runes = runes[:maxEstimateLength]
+	}
+
+	dict := userDictionary(userInputs)
+	charBits := math.Log2(float64(cardinality(password)))
+
+	// best[i] is the lowest number of bits needed to guess runes[:i].
+	best := make([]float64, len(runes)+1)
+	for i := 1; i <= len(runes); i++ {
+		best[i] = math.Inf(1)
+	}
+
+	for i := 0; i < len(runes); i++ {
+		if math.IsInf(best[i], 1) {
+			continue
+		}
+		relax := func(j int, bits float64) {
+			if b := best[i] + bits; b < best[j] {
+				best[j] = b
+			}
+		}
+
+		relax(i+1, charBits)
+		for j := i + 3; j <= len(runes); j++ {
+			if bits, ok := matchBits(runes[i:j], dict); ok {
+				relax(j, bits)
+			}
+		}
+	}
+
+	entropy := best[len(runes)] + float64(tail)*charBits
+	return newStrength(entropy)
+}
+
+// newStrength builds a Strength from an entropy in bits.
+func newStrength(entropy float64) Strength {
+	score := 0
+	for _, threshold := range scoreThresholds {
+		if entropy >= threshold {
+			score++
+		}
+	}
+
+	return Strength{
+		Guesses: math.Pow(2, entropy),
+		Entropy: entropy,
+		Score:   score,
+	}
+}
+
+// matchBits returns the bits needed to guess the segment if it matches a known pattern.
+func matchBits(segment []rune, dict map[string]int) (float64, bool) {
+	bits := math.Inf(1)
+
+	if b, ok := dictionaryBits(segment, dict); ok {
+		bits = min(bits, b)
+	}
+	if b, ok := repeatBits(segment); ok {
+		bits = min(bits, b)
+	}
+	if b, ok := sequenceBits(segment); ok {
+		bits = min(bits, b)
+	}
+	if b, ok := keyboardBits(segment); ok {
+		bits = min(bits, b)
+	}
+	if b, ok := yearBits(segment); ok {
+		bits = min(bits, b)
+	}
+
+	return bits, !math.IsInf(bits, 1)
+}
+
+// dictionaryBits matches common passwords and user inputs, also reversed or with leet substitutions.
+func dictionaryBits(segment []rune, dict map[string]int) (float64, bool) {
+	lower := strings.ToLower(string(segment))
+
+	bits := math.Inf(1)
+	try := func(word string, extra float64) {
+		rank, ok := dict[word]
+		if !ok {
+			rank, ok = commonRanks[word]
+		}
+		if ok {
+			bits = min(bits, math.Log2(float64(rank))+extra)
+		}
+	}
+
+	try(lower, 0)
+	try(reverse(lower), 1)
+	if unleeted := unleet(lower); unleeted != lower {
+		try(unleeted, 1)
+	}
+
+	if math.IsInf(bits, 1) {
+		return 0, false
+	}
+
+	return bits + uppercaseBits(segment), true
+}
+
+// uppercaseBits is the cost of guessing which letters are uppercase.
+func uppercaseBits(segment []rune) float64 {
+	upper, lower := 0, 0
+	for _, r := range segment {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+
+	switch {
+	case upper == 0:
+		return 0
+	case lower == 0 || (upper == 1 && unicode.IsUpper(segment[0])):
+		return 1
+	default:
+		return float64(min(upper, lower)) + 1
+	}
+}
+
+// repeatBits matches runs of the same character, e.g. "aaaa".
+func repeatBits(segment []rune) (float64, bool) {
+	for _, r := range segment[1:] {
+		if r != segment[0] {
+			return 0, false
+		}
+	}
+	return math.Log2(float64(cardinality(string(segment[0])))) + math.Log2(float64(len(segment))), true
+}
+
+// sequenceBits matches runs of consecutive characters, e.g. "abcd" or "9876".
+func sequenceBits(segment []rune) (float64, bool) {
+	delta := segment[1] - segment[0]
+	if delta != 1 && delta != -1 {
+		return 0, false
+	}
+	for i := 2; i < len(segment); i++ {
+		if segment[i]-segment[i-1] != delta {
+			return 0, false
+		}
+	}
+
+	start := 26.0
+	switch {
+	case strings.ContainsRune("aAzZ019", segment[0]):
+		start = 4
+	case unicode.IsDigit(segment[0]):
+		start = 10
+	}
+
+	bits := math.Log2(start) + math.Log2(float64(len(segment)))
+	if delta < 0 {
+		bits++
+	}
+	return bits, true
+}
+
+// keyboardBits matches walks along a keyboard row, e.g. "qwerty" or "asdf".
+func keyboardBits(segment []rune) (float64, bool) {
+	if len(segment) < 4 {
+		return 0, false
+	}
+
+	lower := strings.ToLower(string(segment))
+	for _, row := range keyboardRows {
+		if strings.Contains(row, lower) || strings.Contains(row, reverse(lower)) {
+			return math.Log2(float64(len(keyboardRows)*10)) + math.Log2(float64(len(segment))) + uppercaseBits(segment), true
+		}
+	}
+	return 0, false
+}
+
+// yearBits matches recent years, e.g. "1987" or "2024".
+func yearBits(segment []rune) (float64, bool) {
+	if len(segment) != 4 {
+		return 0, false
+	}
+
+	year := 0
+	for _, r := range segment {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+		year = year*10 + int(r-'0')
+	}
+
+	if year < 1900 || year > 2099 {
+		return 0, false
+	}
+	return math.Log2(200), true
+}
+
+// cardinality returns the size of the character space the password is drawn from.
+func cardinality(password string) int {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range password {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < 128:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	n := 0
+	if lower {
+		n += 26
+	}
+	if upper {
+		n += 26
+	}
+	if digit {
+		n += 10
+	}
+	if symbol {
+		n += 33
+	}
+	if other {
+		n += 100
+	}
+	return max(n, 1)
+}
+
+// userDictionary ranks user inputs and their parts (e.g. the local part of an email) first.
+func userDictionary(inputs []string) map[string]int {
+	dict := map[string]int{}
+	rank := 1
+	add := func(word string) {
+		word = strings.ToLower(word)
+		if len(word) < 3 {
+			return
+		}
+		if _, ok := dict[word]; !ok {
+			dict[word] = rank
+			rank++
+		}
+	}
+
+	for _, input := range inputs {
+		add(input)
+		for _, part := range strings.FieldsFunc(input, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			add(part)
+		}
+	}
+	return dict
+}
+
+// unleet replaces common leet substitutions with the letters they stand for.
+func unleet(s string) string {
+	return strings.Map(func(r rune) rune {
+		if l, ok := leetTable[r]; ok {
+			return l
+		}
+		return r
+	}, s)
+}
+
+// reverse returns s with its runes in reverse order.
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
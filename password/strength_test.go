@@ -0,0 +1,50 @@
+package password
+
+import "testing"
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		inputs   []string
+		maxScore int
+		minScore int
+	}{
+		{name: "common password", password: "password", maxScore: 0},
+		{name: "leet common password", password: "P@ssw0rd", maxScore: 0},
+		{name: "keyboard walk", password: "qwertyuiop", maxScore: 0},
+		{name: "repeat", password: "aaaaaaaaaa", maxScore: 0},
+		{name: "sequence", password: "abcdefgh", maxScore: 0},
+		{name: "reversed sequence", password: "987654321", maxScore: 0},
+		{name: "common with year", password: "dragon1990", maxScore: 1},
+		{name: "user input", password: "johnsmith", inputs: []string{"john.smith@example.com"}, maxScore: 0},
+		{name: "random", password: "kX9#mQ2$vL", minScore: 4, maxScore: 4},
+		{name: "long passphrase", password: "violet-harbor-quantum-lantern", minScore: 4, maxScore: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Estimate(tt.password, tt.inputs...)
+			if got.Score < tt.minScore || got.Score > tt.maxScore {
+				t.Errorf("Estimate(%q).Score = %d (%.1f bits), want between %d and %d",
+					tt.password, got.Score, got.Entropy, tt.minScore, tt.maxScore)
+			}
+		})
+	}
+}
+
+func TestEstimateEmpty(t *testing.T) {
+	got := Estimate("")
+	if got.Score != 0 || got.Entropy != 0 || got.Guesses != 1 {
+		t.Errorf("Estimate(\"\") = %+v, want zero strength", got)
+	}
+}
+
+func TestEstimateLongerIsStronger(t *testing.T) {
+	short := Estimate("kX9#mQ2$")
+	long := Estimate("kX9#mQ2$vL7&")
+
+	if long.Entropy <= short.Entropy {
+		t.Errorf("Estimate() entropy %.1f should exceed %.1f", long.Entropy, short.Entropy)
+	}
+}
@@ -0,0 +1,155 @@
+// Package token issues stateless, expiring tokens for links such as email verification
+// and password reset, so no database row is needed to remember them.
+//
+// A token is the unpadded base64url encoding of its body and signature joined by a dot:
+//
+//	body = version (1 byte) | expiry (8 bytes, unix seconds) | purpose length (1 byte) | purpose | payload
+//
+// The signature is an HMAC-SHA256 over the body and optional binding values. Bindings are not
+// part of the token; binding a reset token to the current password hash invalidates it as soon
+// as the password changes.
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// tokenVersion is the current token format version.
+const tokenVersion = 1
+
+// MinSecretSize is the minimum size of signing secrets in bytes.
+const MinSecretSize = 32
+
+// Errors returned by Verify.
+var (
+	ErrMalformed    = errors.New("token: malformed token")
+	ErrTampered     = errors.New("token: invalid signature")
+	ErrExpired      = errors.New("token: expired")
+	ErrWrongPurpose = errors.New("token: wrong purpose")
+)
+
+// encoding is the URL-safe encoding of tokens, matching utils.Base64Safe.
+var encoding = base64.RawURLEncoding
+
+// Signer signs and verifies tokens.
+type Signer struct {
+	secrets [][]byte
+}
+
+// NewSigner creates a signer that signs with secret.
+// Previous secrets are only used for verification, so secrets can be rotated
+// without invalidating tokens already sent out.
+func NewSigner(secret []byte, previous ...[]byte) (*Signer, error) {
+	s := &Signer{}
+	for _, key := range append([][]byte{secret}, previous...) {
+		if len(key) < MinSecretSize {
+			return nil, errors.New("token: secret must be at least 32 bytes long")
+		}
+		s.secrets = append(s.secrets, append([]byte(nil), key...))
+	}
+	return s, nil
+}
+
+// Sign creates a token for the purpose, e.g. "password-reset", that expires after ttl.
+// The payload, typically a user ID, is readable by anyone holding the token.
+// The same bindings must be passed to Verify.
+func (s *Signer) Sign(purpose, payload string, ttl time.Duration, bindings ...string) (string, error) {
+	return s.sign(time.Now(), purpose, payload, ttl, bindings)
+}
+
+// Verify checks the token and returns its payload.
+// It returns ErrMalformed, ErrTampered, ErrWrongPurpose or ErrExpired.
+// A token whose bindings changed since signing is reported as ErrTampered.
+func (s *Signer) Verify(token, purpose string, bindings ...string) (string, error) {
+	return s.verify(time.Now(), token, purpose, bindings)
+}
+
+// sign creates a token relative to now.
+func (s *Signer) sign(now time.Time, purpose, payload string, ttl time.Duration, bindings []string) (string, error) {
+	if purpose == "" || len(purpose) > math.MaxUint8 {
+		return "", errors.New("token: purpose must be 1 to 255 bytes long")
+	}
+	if ttl <= 0 {
+		return "", errors.New("token: ttl must be greater than 0")
+	}
+
+	body := make([]byte, 0, 10+len(purpose)+len(payload))
+	body = append(body, tokenVersion)
+	body = binary.BigEndian.AppendUint64(body, uint64(now.Add(ttl).Unix()))
+	body = append(body, byte(len(purpose)))
+	body = append(body, purpose...)
+	body = append(body, payload...)
+
+	return encoding.EncodeToString(body) + "." + encoding.EncodeToString(signature(s.secrets[0], body, bindings)), nil
+}
+
+// verify checks a token relative to now.
+func (s *Signer) verify(now time.Time, token, expectedPurpose string, bindings []string) (string, error) {
+	encodedBody, encodedSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", ErrMalformed
+	}
+	body, err := encoding.DecodeString(encodedBody)
+	if err != nil || len(body) < 10 || body[0] != tokenVersion {
+		return "", ErrMalformed
+	}
+	sig, err := encoding.DecodeString(encodedSig)
+	if err != nil || len(sig) != sha256.Size {
+		return "", ErrMalformed
+	}
+
+	purposeLen := int(body[9])
+	if len(body) < 10+purposeLen {
+		return "", ErrMalformed
+	}
+
+	if !s.valid(body, sig, bindings) {
+		return "", ErrTampered
+	}
+
+	if string(body[10:10+purposeLen]) != expectedPurpose {
+		return "", ErrWrongPurpose
+	}
+
+	expiry := binary.BigEndian.Uint64(body[1:9])
+	if expiry > math.MaxInt64 || now.Unix() >= int64(expiry) {
+		return "", ErrExpired
+	}
+
+	return string(body[10+purposeLen:]), nil
+}
+
+// valid reports whether any secret produced the signature.
+func (s *Signer) valid(body, sig []byte, bindings []string) bool {
+	for _, secret := range s.secrets {
+		if hmac.Equal(sig, signature(secret, body, bindings)) {
+			return true
+		}
+	}
+	return false
+}
+
+// signature computes the HMAC-SHA256 of the bindings and body.
+// Bindings are length-prefixed so their boundaries cannot be shifted.
+func signature(secret, body []byte, bindings []string) []byte {
+	mac := hmac.New(sha256.New, secret)
+
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(len(bindings)))
+	mac.Write(n[:])
+	for _, b := range bindings {
+		binary.BigEndian.PutUint64(n[:], uint64(len(b)))
+		mac.Write(n[:])
+		mac.Write([]byte(b))
+	}
+
+	mac.Write(body)
+	return mac.Sum(nil)
+}
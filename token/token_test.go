@@ -0,0 +1,150 @@
+package token
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testSecret    = bytes.Repeat([]byte("k"), MinSecretSize)
+	testOldSecret = bytes.Repeat([]byte("o"), MinSecretSize)
+)
+
+func newTestSigner(t *testing.T, secret []byte, previous ...[]byte) *Signer {
+	t.Helper()
+	s, err := NewSigner(secret, previous...)
+	if err != nil {
+		t.Fatalf("NewSigner() failed: %v", err)
+	}
+	return s
+}
+
+func TestSignVerify(t *testing.T) {
+	s := newTestSigner(t, testSecret)
+
+	tok, err := s.Sign("password-reset", "user:42", time.Hour, "$2a$12$hash")
+	if err != nil {
+		t.Fatalf("Sign() failed: %v", err)
+	}
+	if strings.ContainsAny(tok, "+/=") {
+		t.Errorf("Sign() = %q, want URL-safe token", tok)
+	}
+
+	got, err := s.Verify(tok, "password-reset", "$2a$12$hash")
+	if err != nil {
+		t.Fatalf("Verify() failed: %v", err)
+	}
+	if got != "user:42" {
+		t.Errorf("Verify() = %q, want %q", got, "user:42")
+	}
+}
+
+func TestVerifyErrors(t *testing.T) {
+	s := newTestSigner(t, testSecret)
+	now := time.Unix(1700000000, 0)
+
+	tok, err := s.sign(now, "email-verify", "user:42", time.Hour, []string{"a@example.com"})
+	if err != nil {
+		t.Fatalf("sign() failed: %v", err)
+	}
+
+	body, sig, _ := strings.Cut(tok, ".")
+	raw, _ := encoding.DecodeString(body)
+	raw[len(raw)-1] ^= 1
+	tamperedBody := encoding.EncodeToString(raw)
+
+	tests := []struct {
+		name     string
+		signer   *Signer
+		at       time.Time
+		token    string
+		purpose  string
+		bindings []string
+		wantErr  error
+	}{
+		{name: "valid", signer: s, at: now, token: tok, purpose: "email-verify", bindings: []string{"a@example.com"}},
+		{name: "expired", signer: s, at: now.Add(time.Hour), token: tok, purpose: "email-verify", bindings: []string{"a@example.com"}, wantErr: ErrExpired},
+		{name: "wrong purpose", signer: s, at: now, token: tok, purpose: "password-reset", bindings: []string{"a@example.com"}, wantErr: ErrWrongPurpose},
+		{name: "binding changed", signer: s, at: now, token: tok, purpose: "email-verify", bindings: []string{"b@example.com"}, wantErr: ErrTampered},
+		{name: "binding missing", signer: s, at: now, token: tok, purpose: "email-verify", wantErr: ErrTampered},
+		{name: "tampered body", signer: s, at: now, token: tamperedBody + "." + sig, purpose: "email-verify", bindings: []string{"a@example.com"}, wantErr: ErrTampered},
+		{name: "other secret", signer: newTestSigner(t, testOldSecret), at: now, token: tok, purpose: "email-verify", bindings: []string{"a@example.com"}, wantErr: ErrTampered},
+		{name: "missing signature", signer: s, at: now, token: body, purpose: "email-verify", wantErr: ErrMalformed},
+		{name: "invalid encoding", signer: s, at: now, token: "!!." + sig, purpose: "email-verify", wantErr: ErrMalformed},
+		{name: "empty", signer: s, at: now, token: "", purpose: "email-verify", wantErr: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.signer.verify(tt.at, tt.token, tt.purpose, tt.bindings)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBindingBoundaries(t *testing.T) {
+	s := newTestSigner(t, testSecret)
+
+	tok, err := s.Sign("invite", "", time.Hour, "ab", "c")
+	if err != nil {
+		t.Fatalf("Sign() failed: %v", err)
+	}
+	if _, err := s.Verify(tok, "invite", "a", "bc"); !errors.Is(err, ErrTampered) {
+		t.Errorf("Verify() error = %v, want ErrTampered", err)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	old := newTestSigner(t, testOldSecret)
+	rotated := newTestSigner(t, testSecret, testOldSecret)
+
+	tok, err := old.Sign("password-reset", "user:42", time.Hour)
+	if err != nil {
+		t.Fatalf("Sign() failed: %v", err)
+	}
+	if got, err := rotated.Verify(tok, "password-reset"); err != nil || got != "user:42" {
+		t.Errorf("Verify() = (%q, %v), want token signed with previous secret accepted", got, err)
+	}
+
+	tok, err = rotated.Sign("password-reset", "user:42", time.Hour)
+	if err != nil {
+		t.Fatalf("Sign() failed: %v", err)
+	}
+	if _, err := old.Verify(tok, "password-reset"); !errors.Is(err, ErrTampered) {
+		t.Errorf("Verify() error = %v, want ErrTampered", err)
+	}
+}
+
+func TestSignErrors(t *testing.T) {
+	s := newTestSigner(t, testSecret)
+
+	tests := []struct {
+		name    string
+		purpose string
+		ttl     time.Duration
+	}{
+		{name: "empty purpose", purpose: "", ttl: time.Hour},
+		{name: "long purpose", purpose: strings.Repeat("p", 256), ttl: time.Hour},
+		{name: "zero ttl", purpose: "invite", ttl: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Sign(tt.purpose, "user:42", tt.ttl); err == nil {
+				t.Error("Sign() should return error")
+			}
+		})
+	}
+
+	if _, err := NewSigner([]byte("short")); err == nil {
+		t.Error("NewSigner() should return error for short secret")
+	}
+	if _, err := NewSigner(testSecret, []byte("short")); err == nil {
+		t.Error("NewSigner() should return error for short previous secret")
+	}
+}